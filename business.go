@@ -0,0 +1,100 @@
+package datelp
+
+import (
+	"time"
+)
+
+// BusinessCalendar decides which days are counted as business days, such as
+// by "in 3 business days"
+type BusinessCalendar struct {
+	Weekend  []int            // WEEKDAY_* constants that are never business days, saturday and sunday when nil
+	Holidays *HolidayCalendar // holidays that are not business days, none when nil
+}
+
+// DefaultBusinessCalendar is used by classifiers which were not given a
+// calendar with WithBusinessCalendar. Every day but saturday and sunday is a
+// business day.
+var DefaultBusinessCalendar = BusinessCalendar{}
+
+// IsBusinessDay returns whether the calendar day of t is a business day
+func (bc BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	if bc.weekend()[t.Weekday()] {
+		return false
+	}
+
+	if bc.Holidays != nil {
+		if _, exists := bc.Holidays.On(t); exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddBusinessDays returns the time n business days after t, or before it when
+// n is negative. The time of day of t is kept. Counting starts from the day
+// after t, so that one business day after a friday is the next monday.
+func (bc BusinessCalendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	// a weekend of every day of the week leaves no business days to count,
+	// while holidays only ever cover some of them
+	if len(bc.weekend()) == 7 {
+		return t
+	}
+
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if bc.IsBusinessDay(t) {
+			n -= 1
+		}
+	}
+
+	return t
+}
+
+// weekend returns the days of the week which are never business days
+func (bc BusinessCalendar) weekend() map[time.Weekday]bool {
+	weekend := bc.Weekend
+	if weekend == nil {
+		weekend = []int{WEEKDAY_SATURDAY, WEEKDAY_SUNDAY}
+	}
+
+	weekdays := make(map[time.Weekday]bool)
+	for _, value := range weekend {
+		if weekday, err := ConstantToWeekday(value); err == nil {
+			weekdays[weekday] = true
+		}
+	}
+
+	return weekdays
+}
+
+// BusinessDaysBetween returns the number of business days from the calendar
+// day of from up to, but not including, the calendar day of to. It is
+// negative when to is before from.
+func (bc BusinessCalendar) BusinessDaysBetween(from, to time.Time) int {
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+
+	count := 0
+	end := periodStart(to, INTERVAL_DAY)
+	for day := periodStart(from, INTERVAL_DAY); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if bc.IsBusinessDay(day) {
+			count += 1
+		}
+	}
+
+	return sign * count
+}
+
+// BusinessDaysBetween counts business days the same way as the method of the
+// same name of DefaultBusinessCalendar
+func BusinessDaysBetween(from, to time.Time) int {
+	return DefaultBusinessCalendar.BusinessDaysBetween(from, to)
+}
@@ -0,0 +1,75 @@
+package datelp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddBusinessDays(t *testing.T) {
+	// a friday
+	origin := time.Date(2015, time.May, 22, 9, 0, 0, 0, time.UTC)
+	memorial, _ := Holidays.Lookup("memorial day")
+
+	testCases := []struct {
+		calendar BusinessCalendar
+		days     int
+		expected time.Time
+	}{
+		{BusinessCalendar{}, 1, time.Date(2015, time.May, 25, 9, 0, 0, 0, time.UTC)},
+		{BusinessCalendar{}, -1, time.Date(2015, time.May, 21, 9, 0, 0, 0, time.UTC)},
+		{BusinessCalendar{}, 0, origin},
+		{BusinessCalendar{Holidays: NewHolidayCalendar(memorial)}, 1, time.Date(2015, time.May, 26, 9, 0, 0, 0, time.UTC)},
+		{BusinessCalendar{Weekend: []int{WEEKDAY_FRIDAY, WEEKDAY_SATURDAY}}, 1, time.Date(2015, time.May, 24, 9, 0, 0, 0, time.UTC)},
+		{BusinessCalendar{}, 10, time.Date(2015, time.June, 5, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		if actual := tc.calendar.AddBusinessDays(origin, tc.days); !actual.Equal(tc.expected) {
+			t.Errorf("Wrong date %d business days away. expected: %s actual: %s", tc.days, tc.expected, actual)
+		}
+	}
+}
+
+func TestAddBusinessDaysOverLongHolidays(t *testing.T) {
+	// a wednesday before a shutdown from christmas eve to new year's day
+	origin := time.Date(2015, time.December, 23, 9, 0, 0, 0, time.UTC)
+	calendar := BusinessCalendar{Holidays: NewHolidayCalendar()}
+	for day := 24; day <= 31; day++ {
+		calendar.Holidays.Add(Holiday{Names: []string{"shutdown"}, Rule: FixedHoliday(MONTH_DECEMBER, day)})
+	}
+	calendar.Holidays.Add(Holiday{Names: []string{"new year"}, Rule: FixedHoliday(MONTH_JANUARY, 1)})
+
+	expected := time.Date(2016, time.January, 4, 9, 0, 0, 0, time.UTC)
+	if actual := calendar.AddBusinessDays(origin, 1); !actual.Equal(expected) {
+		t.Errorf("Wrong date 1 business day away. expected: %s actual: %s", expected, actual)
+	}
+
+	expected = origin
+	if actual := calendar.AddBusinessDays(expected.AddDate(0, 0, 12), -1); !actual.Equal(expected) {
+		t.Errorf("Wrong date -1 business days away. expected: %s actual: %s", expected, actual)
+	}
+
+	weekend := BusinessCalendar{Weekend: []int{WEEKDAY_MONDAY, WEEKDAY_TUESDAY, WEEKDAY_WEDNESDAY, WEEKDAY_THURSDAY, WEEKDAY_FRIDAY, WEEKDAY_SATURDAY, WEEKDAY_SUNDAY}}
+	if actual := weekend.AddBusinessDays(origin, 1); !actual.Equal(origin) {
+		t.Errorf("A calendar without business days should not move. actual: %s", actual)
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	from := time.Date(2015, time.May, 22, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2015, time.June, 1, 17, 0, 0, 0, time.UTC)
+
+	if days := BusinessDaysBetween(from, to); days != 6 {
+		t.Errorf("Expected 6 business days, got %d", days)
+	}
+
+	if days := BusinessDaysBetween(to, from); days != -6 {
+		t.Errorf("Expected -6 business days, got %d", days)
+	}
+
+	memorial, _ := Holidays.Lookup("memorial day")
+	calendar := BusinessCalendar{Holidays: NewHolidayCalendar(memorial)}
+	if days := calendar.BusinessDaysBetween(from, to); days != 5 {
+		t.Errorf("Expected 5 business days, got %d", days)
+	}
+}
@@ -0,0 +1,233 @@
+package datelp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Candidate is one possible interpretation of an ambiguous input, such as
+// "friday" which could refer to this week or the next
+type Candidate struct {
+	Result
+	Score  float64 // likelihood of the interpretation, between 0 and 1
+	Reason string  // why the input was interpreted this way
+}
+
+// alternative is one way of reading an ambiguous part of the input. apply
+// changes the contexts of a classifier to match the reading and is nil for
+// the reading that the contexts were built with. When keep is set, readings
+// that it rejects are dropped.
+type alternative struct {
+	score  float64
+	reason string
+	apply  func(c *Classifier)
+	keep   func(date, ref time.Time) bool
+}
+
+// candidates compiles every combination of readings of the ambiguous parts of
+// the contexts built by buildContexts. The reading the contexts were built
+// with always ranks first.
+func (c *Classifier) candidates() ([]Candidate, error) {
+	pinned := c.clone()
+	primary, err := pinned.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	choices := pinned.ambiguities()
+	if len(choices) == 0 {
+		return []Candidate{{Result: *primary, Score: 1, Reason: "unambiguous"}}, nil
+	}
+
+	candidates := make([]Candidate, 0)
+	for _, combination := range combine(choices) {
+		fork := pinned.clone()
+		score := 1.0
+		reasons := make([]string, 0)
+
+		for _, alt := range combination {
+			if alt.apply != nil {
+				alt.apply(fork)
+			}
+			score *= alt.score
+			reasons = append(reasons, alt.reason)
+		}
+
+		result, err := fork.compile()
+		if err != nil || !keep(combination, result.Date, pinned.start) {
+			continue
+		}
+
+		candidates = append(candidates, Candidate{
+			Result: *result,
+			Score:  score,
+			Reason: strings.Join(reasons, ", "),
+		})
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].Score > candidates[b].Score
+	})
+
+	// different readings can land on the same date, only the most likely
+	// of them is kept
+	unique := make([]Candidate, 0)
+	for _, candidate := range candidates {
+		duplicate := false
+		for _, existing := range unique {
+			if existing.Date.Equal(candidate.Date) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			unique = append(unique, candidate)
+		}
+	}
+
+	return unique, nil
+}
+
+// ambiguities returns a choice of readings for every ambiguous part of the
+// contexts. The first reading of each choice is the one the contexts were
+// built with.
+func (c *Classifier) ambiguities() [][]alternative {
+	choices := make([][]alternative, 0)
+
+	// short forms such as "t" are shared by several weekdays
+	if len(c.aliases) > 1 {
+		choice := make([]alternative, 0)
+		for _, value := range c.aliases {
+			value := value
+			weekday, _ := ConstantToWeekday(value)
+
+			choice = append(choice, alternative{
+				score:  1 / float64(len(c.aliases)),
+				reason: fmt.Sprintf("%q read as %s", c.aliasedWord, strings.ToLower(weekday.String())),
+				apply: func(c *Classifier) {
+					c.offset.value = value
+				},
+			})
+		}
+		choices = append(choices, choice)
+	}
+
+	// a numeric date such as 13/06 that was only valid in the other order
+	// has the one reading, which says so
+	if c.misordered {
+		other, _ := reorder(c.dateOrder())
+		choices = append(choices, []alternative{
+			{score: 1, reason: fmt.Sprintf("%q read as %s", c.reorderedWord, orderName(other))},
+		})
+	}
+
+	// the month and day of a numeric date such as 06/01 can be swapped
+	if c.reorderedWord != "" && !c.misordered {
+		reordered := c.reordered
+		other, _ := reorder(c.dateOrder())
+
+		choices = append(choices, []alternative{
+			{score: 0.7, reason: fmt.Sprintf("%q read as %s", c.reorderedWord, orderName(c.dateOrder()))},
+			{score: 0.3, reason: fmt.Sprintf("%q read as %s", c.reorderedWord, orderName(other)), apply: func(c *Classifier) {
+				c.date.year, c.date.month, c.date.monthday = reordered[0], reordered[1], reordered[2]
+			}},
+		})
+	}
+
+	// a weekday on its own could be in this week or an adjacent one
+	if c.offset.interval == INTERVAL_WEEKDAY && c.offset.direction == DIRECTION_CURRENT && c.offset.count == 1 && !c.date.isValid() {
+		choices = append(choices, []alternative{
+			{score: 0.6, reason: "in the current week"},
+			{score: 0.25, reason: "in the following week", apply: func(c *Classifier) {
+				c.offset.direction = DIRECTION_RIGHT
+			}},
+			{score: 0.15, reason: "in the previous week", apply: func(c *Classifier) {
+				c.offset.direction = DIRECTION_LEFT
+			}},
+		})
+	}
+
+	// next june could mean the coming june rather than the one after it
+	if c.offset.interval == INTERVAL_MONTH && c.offset.direction != DIRECTION_CURRENT && c.date.month >= 0 && c.offset.value == c.date.month {
+		month, _ := ConstantToMonth(c.offset.value)
+		name := strings.ToLower(month.String())
+
+		if c.offset.direction == DIRECTION_RIGHT {
+			choices = append(choices, []alternative{
+				{score: 0.6, reason: name + " of the following year"},
+				{score: 0.4, reason: "the coming " + name, apply: func(c *Classifier) {
+					c.offset.direction = DIRECTION_CURRENT
+				}, keep: func(date, ref time.Time) bool {
+					return date.After(ref)
+				}},
+			})
+		} else {
+			choices = append(choices, []alternative{
+				{score: 0.6, reason: name + " of the previous year"},
+				{score: 0.4, reason: "the most recent " + name, apply: func(c *Classifier) {
+					c.offset.direction = DIRECTION_CURRENT
+				}, keep: func(date, ref time.Time) bool {
+					return date.Before(ref)
+				}},
+			})
+		}
+	}
+
+	return choices
+}
+
+// orderName describes one of the ORDER constants
+func orderName(order int) string {
+	switch order {
+	case ORDER_DMY:
+		return "day/month/year"
+	case ORDER_YMD:
+		return "year/month/day"
+	}
+
+	return "month/day/year"
+}
+
+// clone returns a copy of the classifier and its contexts pinned to the same
+// reference time, which can be changed without affecting the original
+func (c *Classifier) clone() *Classifier {
+	clone := *c
+	clone.start = c.reference()
+
+	offset, date, clock := *c.offset, *c.date, *c.clock
+	clone.offset, clone.date, clone.clock = &offset, &date, &clock
+
+	return &clone
+}
+
+// combine returns every combination of one reading from each choice, starting
+// with the combination of the first readings
+func combine(choices [][]alternative) [][]alternative {
+	combinations := [][]alternative{{}}
+
+	for _, choice := range choices {
+		next := make([][]alternative, 0)
+		for _, combination := range combinations {
+			for _, alt := range choice {
+				extended := append(append([]alternative{}, combination...), alt)
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+
+	return combinations
+}
+
+func keep(combination []alternative, date, ref time.Time) bool {
+	for _, alt := range combination {
+		if alt.keep != nil && !alt.keep(date, ref) {
+			return false
+		}
+	}
+
+	return true
+}
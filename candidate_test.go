@@ -0,0 +1,66 @@
+package datelp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifierParseAll(t *testing.T) {
+	ref := sunday
+	june := func(year int) time.Time {
+		return time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		input    string
+		expected []time.Time
+		reasons  []string
+	}{
+		{"friday", []time.Time{ref.AddDate(0, 0, 5), ref.AddDate(0, 0, 12), ref.AddDate(0, 0, -2)},
+			[]string{"in the current week", "in the following week", "in the previous week"}},
+		{"next june", []time.Time{june(2016), june(2015)},
+			[]string{"june of the following year", "the coming june"}},
+		{"next may", []time.Time{time.Date(2016, time.May, 1, 0, 0, 0, 0, time.UTC)},
+			[]string{"may of the following year"}},
+		{"t", []time.Time{ref.AddDate(0, 0, 2), ref.AddDate(0, 0, 3), ref.AddDate(0, 0, 9), ref.AddDate(0, 0, 10), ref.AddDate(0, 0, -5), ref.AddDate(0, 0, -4)},
+			[]string{`"t" read as tuesday, in the current week`, `"t" read as wednesday, in the current week`,
+				`"t" read as tuesday, in the following week`, `"t" read as wednesday, in the following week`,
+				`"t" read as tuesday, in the previous week`, `"t" read as wednesday, in the previous week`}},
+		{"june 1st 2015", []time.Time{june(2015)}, []string{"unambiguous"}},
+		{"06/01/2015", []time.Time{june(2015), time.Date(2015, time.January, 6, 0, 0, 0, 0, time.UTC)},
+			[]string{`"06/01/2015" read as month/day/year`, `"06/01/2015" read as day/month/year`}},
+		{"06/13/2015", []time.Time{time.Date(2015, time.June, 13, 0, 0, 0, 0, time.UTC)}, []string{"unambiguous"}},
+		{"13/06/2015", []time.Time{time.Date(2015, time.June, 13, 0, 0, 0, 0, time.UTC)}, []string{`"13/06/2015" read as day/month/year`}},
+		{"13/6", []time.Time{time.Date(2015, time.June, 13, 0, 0, 0, 0, time.UTC)}, []string{`"13/6" read as day/month/year`}},
+	}
+
+	for _, tc := range testCases {
+		candidates, err := NewClassifier(WithReference(ref)).ParseAll(newWordIterator(tc.input))
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+			continue
+		}
+
+		if len(candidates) != len(tc.expected) {
+			t.Errorf("\"%s\" returned %d candidates. expected: %d", tc.input, len(candidates), len(tc.expected))
+			continue
+		}
+
+		for index, candidate := range candidates {
+			if !candidate.Date.Equal(tc.expected[index]) || candidate.Reason != tc.reasons[index] {
+				t.Errorf("\"%s\" candidate %d was wrong. expected: %s (%s) actual: %s (%s)",
+					tc.input, index, tc.expected[index], tc.reasons[index], candidate.Date, candidate.Reason)
+			}
+
+			if index > 0 && candidate.Score > candidates[index-1].Score {
+				t.Errorf("\"%s\" candidates were not ranked by score", tc.input)
+			}
+		}
+
+		// parse always returns the top ranked candidate
+		res, _ := NewClassifier(WithReference(ref)).Parse(newWordIterator(tc.input))
+		if !res.Date.Equal(candidates[0].Date) {
+			t.Errorf("\"%s\" Parse did not return the top candidate. expected: %s actual: %s", tc.input, candidates[0].Date, res.Date)
+		}
+	}
+}
@@ -2,59 +2,818 @@ package datelp
 
 import (
 	"errors"
+	"io"
+	"strings"
 	"time"
 )
 
 type Classifier struct {
-	offset *OffsetContext
-	date   *DateContext
-	start  time.Time
+	offset   *OffsetContext
+	date     *DateContext
+	clock    *TimeContext
+	start    time.Time
+	location *time.Location
+	language *Language
+	order    int
+	ordered  bool // whether order was set rather than taken from the language
+	strict   bool
+
+	// the holidays that are recognized by name, Holidays when unset
+	holidays *HolidayCalendar
+
+	// decides which days are business days, DefaultBusinessCalendar when
+	// unset
+	business *BusinessCalendar
+
+	// the month that the fiscal year starts in, eg: MONTH_OCTOBER
+	fiscalStart int
+
+	// whether the boundary of a period, such as the end of the month, is
+	// returned as a range covering the day that it falls on
+	boundaryRanges bool
+
+	// invalid is set when a component of a date was found but can not be
+	// valid, such as the day in june 45th, and skipped to the first word
+	// that could not be classified at all
+	invalid error
+	skipped *ParseError
+
+	// the word which set the day of the month, reported when the day
+	// does not exist in its month such as the 30 of feb 30
+	monthday *ParseError
+
+	// the weekdays that an ambiguous short form such as "t" could refer
+	// to, along with the word itself
+	aliases     []int
+	aliasedWord string
+
+	// a numeric date such as 06/01 whose month and day can be read in
+	// either order, along with the year, month and day of the other reading
+	reorderedWord string
+	reordered     [3]int
+
+	// whether the numeric date was only valid when read in the other order,
+	// such as 13/06 when month/day/year is the order
+	misordered bool
+
+	// bookkeeping for extraction. first and last are the indexes of the
+	// first and last tokens that were classified as part of the current
+	// contexts and anchored is set once a token that can only belong to a
+	// date (as opposed to an arbitrary number) was found.
+	extracting bool
+	anchored   bool
+	first      int
+	last       int
+}
+
+// Option configures optional behaviour of a Classifier and is passed to
+// NewClassifier
+type Option func(*Classifier)
+
+// WithReference sets the instant that relative expressions such as
+// "tomorrow" or "3 weeks ago" are resolved against. When unset the
+// classifier falls back to the time at which Parse is called.
+func WithReference(ref time.Time) Option {
+	return func(c *Classifier) {
+		c.start = ref
+	}
+}
+
+// WithLocation sets the time zone that calendar days are computed in. Every
+// returned time carries this location. When unset the location of the
+// reference time is used.
+func WithLocation(location *time.Location) Option {
+	return func(c *Classifier) {
+		c.location = location
+	}
+}
+
+// WithLanguage sets the vocabulary that words are classified with. When unset
+// the classifier reads English.
+func WithLanguage(language *Language) Option {
+	return func(c *Classifier) {
+		c.language = language
+	}
+}
+
+// WithDateOrder sets the order that the day and month of numeric dates such
+// as 06/01/2015 are read in to ORDER_MDY, ORDER_DMY or ORDER_YMD. When unset
+// the Order of the language is used. Dates that start with the year and dates separated by
+// dots, such as 1.6.2015, are not ambiguous and ignore it.
+func WithDateOrder(order int) Option {
+	return func(c *Classifier) {
+		c.order = order
+		c.ordered = true
+	}
+}
+
+// WithStrict requires that every word of the input is part of the date.
+// Instead of ignoring unrecognized words Parse fails with ErrUnrecognized or
+// ErrTrailingInput. Common words such as "the" are always allowed.
+func WithStrict() Option {
+	return func(c *Classifier) {
+		c.strict = true
+	}
+}
+
+// WithHolidays sets the calendar of holidays that are recognized by name, such
+// as "christmas" or "2 days before thanksgiving". When unset the Holidays
+// calendar is used.
+func WithHolidays(calendar *HolidayCalendar) Option {
+	return func(c *Classifier) {
+		c.holidays = calendar
+	}
+}
+
+// WithBusinessCalendar sets the weekend days and holidays which are skipped by
+// business days, such as "in 3 business days". When unset saturday and sunday
+// are skipped.
+func WithBusinessCalendar(calendar BusinessCalendar) Option {
+	return func(c *Classifier) {
+		c.business = &calendar
+	}
+}
+
+// WithFiscalYearStart sets the month that the fiscal year starts in, such as
+// MONTH_OCTOBER, which quarters, halves and fiscal years are counted from.
+// When unset the fiscal year is the calendar year. Fiscal years are named
+// after the calendar year that they end in, so that FY2017 starting in
+// october runs from october 2016 to september 2017.
+func WithFiscalYearStart(month int) Option {
+	return func(c *Classifier) {
+		c.fiscalStart = month
+	}
+}
+
+// WithBoundaryRanges returns the boundary of a period, such as "end of the
+// month", as a KIND_RANGE result covering the whole day that it falls on
+// rather than a single instant
+func WithBoundaryRanges() Option {
+	return func(c *Classifier) {
+		c.boundaryRanges = true
+	}
+}
+
+func NewClassifier(options ...Option) *Classifier {
+	c := &Classifier{}
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// reference returns the instant that every compile path should be relative
+// to, in the location that calendar days should be computed in
+func (c *Classifier) reference() time.Time {
+	start := c.start
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	if c.location != nil {
+		start = start.In(c.location)
+	}
+
+	return start
+}
+
+// lang returns the language that words should be classified with
+func (c *Classifier) lang() *Language {
+	if c.language == nil {
+		return English
+	}
+
+	return c.language
+}
+
+// dateOrder returns the order that numeric dates are read in
+func (c *Classifier) dateOrder() int {
+	if c.ordered {
+		return c.order
+	}
+
+	return c.lang().Order
+}
+
+// calendar returns the holidays that should be recognized by name
+func (c *Classifier) calendar() *HolidayCalendar {
+	if c.holidays == nil {
+		return Holidays
+	}
+
+	return c.holidays
 }
 
-func NewClassifier() *Classifier {
-	return &Classifier{}
+// fork returns a classifier with the same configuration and reference time,
+// used to classify part of an expression such as one end of a range in
+// isolation
+func (c *Classifier) fork() *Classifier {
+	fork := *c
+	fork.start = c.reference()
+	fork.extracting = true
+
+	return &fork
 }
 
+// Parse returns the most likely interpretation of the date at the current
+// position of the iterator
 func (c *Classifier) Parse(i Iterator) (*Result, error) {
+	candidates, err := c.ParseAll(i)
+	if err != nil {
+		return nil, err
+	}
+
+	return &candidates[0].Result, nil
+}
+
+// ParseAll returns every plausible interpretation of the date at the current
+// position of the iterator, ordered from the most to the least likely
+func (c *Classifier) ParseAll(i Iterator) ([]Candidate, error) {
+	if _, err := i.NextNth(0); err != nil {
+		return nil, &ParseError{Err: ErrNoDate, Index: i.Index()}
+	}
+
+	// recurrences and ranges span several dates which could each be built
+	// into contexts, so they are looked for before anything else, at the
+	// words where one could start
+	origin := i.Index()
+	token := i.Current()
+	for {
+		if c.startsExpression(i) {
+			if result, err := c.parseExpression(i); err == nil {
+				if c.strict {
+					if err := c.trailing(i, i.Index()+result.Size); err != nil {
+						return nil, err
+					}
+				}
+
+				return []Candidate{{Result: *result, Score: 1, Reason: "unambiguous"}}, nil
+			} else if invalidExpression(err) {
+				return nil, err
+			}
+		}
+
+		// a strict parse has to start at the first word
+		if err := i.Move(); c.strict || err != nil {
+			break
+		}
+	}
+	i.MoveN(origin - i.Index())
+
 	err := c.buildContexts(i)
-	if err != nil || (c.offset.size == 0 && c.date.size == 0) {
+	if err != nil || (c.offset.size == 0 && c.date.size == 0 && c.clock.size == 0) {
 		// neither context could be built, exit and emit an error
-		return nil, errors.New("Unable to build any context. No date parseable")
+		return nil, &ParseError{Err: ErrNoDate, Token: token, Index: origin}
+	}
+
+	if c.invalid != nil {
+		return nil, c.invalid
+	}
+
+	if c.strict {
+		if c.skipped != nil && c.skipped.Index < c.last {
+			return nil, c.skipped
+		}
+
+		if err := c.trailing(i, c.last+1); err != nil {
+			return nil, err
+		}
 	}
 
-	result := &Result{Size: MaxInt(c.offset.size, c.date.size), Date: time.Now()}
+	return c.candidates()
+}
+
+// trailing returns an error for the first word from index onwards which is
+// not a common word
+func (c *Classifier) trailing(i Iterator, index int) error {
+	if err := i.MoveN(index - i.Index()); err != nil {
+		return nil
+	}
+
+	for {
+		if _, err := c.lang().ClassifyAsCommon(i); err != nil {
+			return newParseError(ErrTrailingInput, i, "")
+		}
+
+		if err := i.Move(); err != nil {
+			return nil
+		}
+	}
+}
+
+// parseExpression looks for an expression which spans several contexts, such
+// as a recurrence or range, at the current position of the iterator without
+// moving it
+func (c *Classifier) parseExpression(i Iterator) (*Result, error) {
+	// each occurrence of a recurrence is a day
+	r, count, err := c.parseRecurrence(i)
+	if err == nil {
+		return &Result{Size: count, Date: r.Next(c.reference()), Kind: KIND_RECURRENCE, Recurrence: r, Precision: INTERVAL_DAY}, nil
+	} else if invalidExpression(err) {
+		return nil, err
+	}
+
+	result, count, err := c.parseRange(i)
+	if err == nil {
+		result.Size = count
+		return result, nil
+	} else if invalidExpression(err) {
+		return nil, err
+	}
+
+	return nil, newParseError(ErrUnrecognized, i, "recurrence or range")
+}
+
+// invalidExpression returns whether an error of parseExpression means that a
+// recurrence or range was found but is not valid, such as every 0 days, rather
+// than that there was none
+func invalidExpression(err error) bool {
+	return !errors.Is(err, ErrUnrecognized) && !errors.Is(err, ErrOutOfRange)
+}
+
+// compile turns the contexts built by buildContexts into a Result
+func (c *Classifier) compile() (*Result, error) {
+	start := c.reference()
+	result := &Result{
+		Size: MaxInt(c.offset.size, c.date.size) + c.clock.size,
+		Date: start,
+		Kind: KIND_OFFSET,
+	}
+
+	if c.offset.size == 0 && c.date.size == 0 {
+		result.Kind = KIND_TIME
+	}
+
+	if c.date.ordinal != 0 || c.date.boundary >= 0 {
+		date, err := c.compileSelector(start)
+		if err != nil {
+			return nil, err
+		}
+		result.Date = date
+		result.Kind = KIND_DATE
+
+		// a boundary can be widened to the whole day that it falls on
+		if c.date.boundary >= 0 && c.boundaryRanges {
+			day := periodStart(date, INTERVAL_DAY)
+			result.Kind = KIND_RANGE
+			result.Range = &Range{Start: day, End: periodEnd(day, INTERVAL_DAY)}
+		}
+	} else if c.date.span >= 0 || c.quarterOffset() {
+		r, err := c.compileSpan(start)
+		if err != nil {
+			return nil, err
+		}
+		result.Date = r.Start
+		result.Kind = KIND_RANGE
+		result.Range = r
+	} else {
+		if c.date.isValid() {
+			date, err := c.compileDate(start)
+			if err != nil {
+				return nil, err
+			}
+			result.Date = date
+			result.Kind = KIND_DATE
+		}
+
+		date, err := c.offset.Compile(result.Date)
+		if err != nil {
+			return result, nil
+		}
 
-	if c.date.isValid() {
-		date, err := c.date.Compile()
+		if result.Kind == KIND_DATE && !date.Equal(result.Date) {
+			result.Kind = KIND_COMBINED
+		}
+		result.Date = date
+	}
+
+	// the time of day is applied last so that it is kept regardless of
+	// which day the date and offset contexts landed on
+	if c.clock.size > 0 {
+		date, err := c.clock.Compile(result.Date)
 		if err != nil {
 			return nil, err
 		}
 		result.Date = date
 	}
 
-	date, err := c.offset.Compile(result.Date)
+	c.precision(result)
+	return result, nil
+}
+
+// precision records the finest interval that the contexts expressed on the
+// result, along with the components that they expressed and the period which
+// covers the date
+func (c *Classifier) precision(result *Result) {
+	date := c.date
+	precision := c.offset.truncate
+
+	if date.year > 0 {
+		precision = finer(precision, INTERVAL_YEAR)
+	}
+
+	/* a day selected inside of a period, or its boundary, is as precise as
+	a day however wide the period is, while a period such as a quarter or
+	the week ending friday covers all of it even when it was picked by a day */
+	switch {
+	case date.ordinal != 0 && date.unit == INTERVAL_WEEK:
+		precision = finer(precision, INTERVAL_WEEK)
+	case date.ordinal != 0, date.boundary >= 0:
+		precision = finer(precision, INTERVAL_DAY)
+	case date.span >= 0:
+		precision = date.span
+	case c.quarterOffset():
+		precision = finer(precision, INTERVAL_QUARTER)
+	case date.monthday > 0, date.synonym >= 0, date.holiday != nil:
+		precision = finer(precision, INTERVAL_DAY)
+	case date.month >= 0:
+		precision = finer(precision, INTERVAL_MONTH)
+	}
+
+	if c.clock.size > 0 {
+		precision = finer(precision, c.clock.precision)
+	}
+
+	// ISO weeks are reported as weeks, and a time of day on its own is on
+	// the reference day
+	if precision == INTERVAL_ISO_WEEK {
+		precision = INTERVAL_WEEK
+	} else if finer(precision, INTERVAL_YEAR) != precision {
+		precision = INTERVAL_DAY
+	}
+
+	/* components coarser than the precision are expressed by an offset
+	from the reference or a synonym such as tomorrow, unless the offset is
+	counted from a date which was written out as in 2 days after june 1st */
+	absolute := date.year > 0 || date.month >= 0 || date.monthday > 0 || date.holiday != nil || date.week > 0
+	relative := date.synonym >= 0 || (c.offset.interval >= 0 && !absolute)
+	expressed := func(interval int) bool {
+		return relative && finer(precision, interval) == precision
+	}
+
+	// the year of "next june" is picked by the offset, while the year of a
+	// lone "june" is the year of the reference
+	pickedYear := c.offset.interval == INTERVAL_MONTH && c.offset.value >= 0 && c.offset.direction != DIRECTION_CURRENT
+	clock := c.clock.size > 0
+
+	result.Precision = precision
+	result.Explicit = Components{
+		Year:   date.year > 0 || pickedYear || expressed(INTERVAL_YEAR),
+		Month:  date.month >= 0 || date.holiday != nil || c.offset.value >= 0 && c.offset.interval == INTERVAL_MONTH || expressed(INTERVAL_MONTH),
+		Day:    date.monthday > 0 || date.holiday != nil || (date.ordinal != 0 && date.unit != INTERVAL_WEEK) || date.boundary >= 0 || expressed(INTERVAL_DAY),
+		Hour:   clock || expressed(INTERVAL_HOUR),
+		Minute: (clock && c.clock.precision != INTERVAL_HOUR) || expressed(INTERVAL_MINUTE),
+	}
+
+	if result.Range != nil {
+		result.Period = result.Range
+		return
+	}
+
+	// weeks selected inside of a period start on the day that they were
+	// counted from, eg: the first week of june starts on june 1st
+	start := truncate(result.Date, precision)
+	if date.ordinal != 0 && date.unit == INTERVAL_WEEK {
+		start = truncate(result.Date, INTERVAL_DAY)
+	}
+	result.Period = &Range{Start: start, End: periodEnd(start, precision)}
+}
+
+// compileDate compiles the date context, pointing a day which does not exist
+// in its month at the word that it was written in
+func (c *Classifier) compileDate(start time.Time) (time.Time, error) {
+	date, err := c.date.Compile(start)
+	if errors.Is(err, ErrDayOutOfRange) && c.monthday != nil {
+		return date, c.monthday
+	}
+
+	return date, err
+}
+
+// compileSelector resolves a day selected by its position inside of a period,
+// or the boundary of a period. The period is found by applying the explicit
+// day, month or year of the date context and then the offset, as in "next
+// month", to the start of the period that start falls in.
+func (c *Classifier) compileSelector(start time.Time) (time.Time, error) {
+	origin := start
+	if c.date.span >= 0 {
+		date, err := c.date.compileSpan(start)
+		if err != nil {
+			return start, err
+		}
+		origin = date
+	} else if c.date.synonym >= 0 || c.date.holiday != nil || c.date.month >= 0 || c.date.year > 0 {
+		date, err := c.compileDate(start)
+		if err != nil {
+			return start, err
+		}
+		origin = date
+	}
+
+	origin, err := c.offset.Compile(fiscalPeriodStart(origin, c.date.period, c.date.fiscal))
 	if err != nil {
-		return result, nil
+		return start, err
 	}
-	result.Date = date
 
-	return result, nil
+	if c.date.ordinal == 0 {
+		return c.date.compileBoundary(origin)
+	}
+
+	return c.date.compileRelative(origin)
+}
+
+// compileSpan resolves a quarter, half, fiscal year or week into a range
+// covering all of it. The offset moves the range by whole periods, as in "last
+// quarter".
+func (c *Classifier) compileSpan(start time.Time) (*Range, error) {
+	date := *c.date
+	if date.span < 0 {
+		date.span = INTERVAL_QUARTER
+	}
+
+	origin, err := date.compileSpan(start)
+	if err != nil {
+		return nil, err
+	}
+
+	origin, err = c.offset.Compile(origin)
+	if err != nil {
+		return nil, err
+	}
+
+	// a week ending on a day is the 7 days up to and including it
+	if date.ending {
+		from := periodStart(origin, INTERVAL_DAY).AddDate(0, 0, -6)
+		return &Range{Start: from, End: from.AddDate(0, 0, 7)}, nil
+	}
+
+	from := fiscalPeriodStart(origin, date.span, date.fiscal)
+	return &Range{Start: from, End: periodEnd(from, date.span)}, nil
+}
+
+// quarterOffset returns whether the offset counts whole quarters and nothing
+// else, such as "last quarter", which refers to all of the quarter
+func (c *Classifier) quarterOffset() bool {
+	return c.offset.interval == INTERVAL_QUARTER && len(c.offset.terms) == 0 && !c.date.isValid()
+}
+
+// Extract walks the entire iterator and returns every date mention found
+// along with the byte offsets of the tokens that it was built from. The
+// contexts are reset between mentions so that each one is compiled in
+// isolation. Match.Text is left empty since the iterator does not hold the
+// original input.
+func (c *Classifier) Extract(i Iterator) []Match {
+	matches := make([]Match, 0)
+	c.extract(i, func(match Match) {
+		matches = append(matches, match)
+	})
+
+	return matches
+}
+
+// ExtractFrom extracts date mentions from input as it is read, passing each
+// one to fn with its Text filled in as soon as it is found. Only a window of
+// words around the current one is held in memory, so that input can be
+// arbitrarily large. It returns the first error of reading input.
+func (c *Classifier) ExtractFrom(input io.Reader, fn func(Match)) error {
+	iterator := NewStreamIterator(input, StreamWindow)
+
+	// an empty iterator has no current word to classify
+	if _, err := iterator.NextNth(0); err != nil {
+		return iterator.Err()
+	}
+
+	c.extract(iterator, func(match Match) {
+		match.Text = iterator.text(match.Start, match.End)
+		fn(match)
+	})
+
+	return iterator.Err()
+}
+
+// extract calls fn with each date mention of the iterator, in the order that
+// they appear
+func (c *Classifier) extract(i Iterator, fn func(Match)) {
+	c.extracting = true
+	defer func() { c.extracting = false }()
+
+	for {
+		if _, err := c.lang().ClassifyAsCommon(i); err != nil {
+			origin := i.Index()
+			match, err := Match{}, error(ErrUnrecognized)
+			if c.startsExpression(i) {
+				match, err = c.extractExpression(i)
+			}
+			if err != nil {
+				match, err = c.extractMatch(i)
+			}
+			if err == nil {
+				fn(match)
+				origin = c.last
+			}
+
+			// continue looking from the token after the mention, or
+			// the token after the origin if nothing was found
+			if err := i.MoveN(origin - i.Index()); err != nil {
+				break
+			}
+		}
+
+		if err := i.Move(); err != nil {
+			break
+		}
+	}
+}
+
+// startsExpression returns whether a recurrence or range could start at the
+// current word of the iterator, so that extraction only searches for them
+// where they can be found. A range has to start with a word such as "from" or
+// with a date which is followed by a separator such as "to", or with a dashed
+// span such as "1-5".
+func (c *Classifier) startsExpression(i Iterator) bool {
+	l := c.lang()
+	word := i.Current()
+	if contains(l.Every, word) || contains(l.On, word) || contains(l.From, word) || contains(l.Between, word) {
+		return true
+	}
+
+	if _, err := l.ClassifyAsFrequency(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsPluralWeekday(i); err == nil {
+		return true
+	}
+
+	for n := 0; n < rangeWindow; n++ {
+		next, err := i.NextNth(n)
+		if err != nil {
+			break
+		}
+
+		if strings.Contains(next, "-") || contains(l.To, next) || contains(l.Until, next) || contains(l.And, next) {
+			return c.isDateWord(i)
+		}
+	}
+
+	return false
+}
+
+// isDateWord returns whether the current word of the iterator is part of a
+// date on its own, such as a month, a number or "next"
+func (c *Classifier) isDateWord(i Iterator) bool {
+	l := c.lang()
+	if _, _, err := l.ClassifyWordAsInteger(i.Current()); err == nil {
+		return true
+	}
+
+	if dayRange.MatchString(i.Current()) {
+		return true
+	}
+
+	if _, _, _, err := l.ClassifyAsNumericDate(i, c.dateOrder()); err == nil {
+		return true
+	}
+
+	if _, _, err := l.ClassifyAsTimeOfDay(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsMonth(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsWeekday(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsDaySynonym(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsDirection(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsInterval(i); err == nil {
+		return true
+	}
+
+	if _, _, err := l.ClassifyAsOrdinal(i); err == nil {
+		return true
+	}
+
+	if _, err := l.ClassifyAsBoundary(i); err == nil {
+		return true
+	}
+
+	if _, _, err := ClassifyAsFiscalPeriod(i); err == nil {
+		return true
+	}
+
+	_, _, err := c.calendar().ClassifyAsHoliday(i)
+	return err == nil
+}
+
+func (c *Classifier) extractExpression(i Iterator) (Match, error) {
+	result, err := c.parseExpression(i)
+	if err != nil {
+		return Match{}, err
+	}
+
+	c.first = i.Index()
+	c.last = c.first + result.Size - 1
+
+	start, _ := i.Span()
+	if err := i.MoveN(result.Size - 1); err != nil {
+		return Match{}, err
+	}
+	_, end := i.Span()
+
+	return Match{
+		Result: *result,
+		Start:  start,
+		End:    end,
+	}, nil
+}
+
+func (c *Classifier) extractMatch(i Iterator) (Match, error) {
+	origin := newParseError(ErrNoDate, i, "")
+	if err := c.buildContexts(i); err != nil || !c.anchored || c.invalid != nil {
+		return Match{}, origin
+	}
+
+	result, err := c.compile()
+	if err != nil {
+		return Match{}, err
+	}
+
+	// trailing common words can be swallowed by an integer stem, such as
+	// "three and", so trim them from the span of the mention
+	for c.last > c.first {
+		if err := i.MoveN(c.last - i.Index()); err != nil {
+			break
+		}
+
+		if _, err := c.lang().ClassifyAsCommon(i); err != nil {
+			break
+		}
+		c.last -= 1
+	}
+
+	if err := i.MoveN(c.first - i.Index()); err != nil {
+		return Match{}, origin
+	}
+	start, _ := i.Span()
+	if err := i.MoveN(c.last - i.Index()); err != nil {
+		return Match{}, origin
+	}
+	_, end := i.Span()
+
+	return Match{
+		Result: *result,
+		Start:  start,
+		End:    end,
+	}, nil
 }
 
 func (c *Classifier) buildContexts(i Iterator) error {
 	errs := 0
 	successes := 0
+	origin := newParseError(ErrNoDate, i, "")
+	start := i.Index()
 
 	c.offset = &OffsetContext{
-		interval: 0,
+		interval: -1,
 		count:    1,
+		value:    -1,
+		truncate: -1,
 		size:     0,
+		calendar: c.business,
 	}
 	c.date = &DateContext{
-		size:    0,
-		weekday: -1,
-		synonym: -1,
+		size:     0,
+		weekday:  -1,
+		synonym:  -1,
+		month:    -1,
+		boundary: -1,
+		span:     -1,
+		fiscal:   c.fiscalShift(),
 	}
+	c.clock = &TimeContext{
+		size: 0,
+	}
+	c.anchored = false
+	c.invalid = nil
+	c.skipped = nil
+	c.monthday = nil
+	c.aliases = nil
+	c.aliasedWord = ""
+	c.reorderedWord = ""
+	c.misordered = false
+	c.first = -1
+	c.last = -1
 
 	// its worth mentioning that this element loops through the element as
 	// both a date and offset context. The goal here is to be able to
@@ -62,112 +821,653 @@ func (c *Classifier) buildContexts(i Iterator) error {
 	// another. When both an offset and date context are found, then we use
 	// the date as the "starting" point for the offset.
 	for {
-		offsetCount, offsetErr := c.parseOffset(i)
-		dateCount, dateErr := c.parseDate(i)
+		// when extracting, a mention ends at punctuation such as a full
+		// stop, and never takes in a number which neither counts nor
+		// dates anything, such as the 555 of call 555 1234 friday. It is
+		// never longer than the longest expression either, so that a
+		// long run of words such as "the the the" neither reads past
+		// the window of a StreamIterator nor is read again from each of
+		// its words.
+		if c.extracting && ((c.first >= 0 && i.Separated()) || c.looseNumber(i) || i.Index()-start >= rangeWindow) {
+			break
+		}
+
+		_, commonErr := c.lang().ClassifyAsCommon(i)
+		index := i.Index()
+
+		// a time of day such as 3pm or 14:30 would otherwise be picked
+		// up as a number by both of the other contexts, as would the
+		// ordinal of a selector such as the third wednesday of june
+		count, err := c.parseSelector(i)
+		if err != nil {
+			count, err = c.parseBoundary(i)
+		}
+		if err != nil {
+			count, err = c.parseFiscal(i)
+		}
+		if err != nil {
+			count, err = c.parseWeek(i)
+		}
+		if err != nil {
+			count, err = c.parseTime(i)
+		}
+		if err != nil {
+			offsetCount, offsetErr := c.parseOffset(i)
+			dateCount, dateErr := c.parseDate(i)
+			count = MaxInt(offsetCount, dateCount)
 
-		if offsetErr != nil && dateErr != nil {
+			if offsetErr == nil || dateErr == nil {
+				err = nil
+			}
+		}
+
+		if err != nil {
 			errs += 1
+			if c.skipped == nil {
+				c.skipped = newParseError(ErrUnrecognized, i, "date")
+			}
+
+			// when extracting, a mention ends at the first word which
+			// can not be classified
+			if c.extracting {
+				break
+			}
 		} else {
-			successes += MaxInt(offsetCount, dateCount)
+			successes += count
+			if commonErr != nil {
+				if c.first < 0 {
+					c.first = index
+				}
+				c.last = index + count - 1
+			}
 		}
 
 		// if 4 errs in a row have happened or we are at the end of the
 		// iterator, break because it can be assumed that nothing of
 		// importance was actually found
-		if err := i.MoveN(MaxInt(offsetCount, dateCount)); errs == 4 || err != nil {
+		if err := i.MoveN(count); errs == 4 || err != nil {
 			break
 		}
 	}
 
 	if successes == 0 {
-		return errors.New("Nothing found")
+		return origin
 	}
 
 	return nil
 }
 
+// looseNumber returns whether the current word is a number written in digits
+// which is not part of a date. A number belongs to a date when the date has a
+// month, as the day and year of june 1 2015 do, or when the next word that is
+// not a common word is an interval or a month, as in 3 days or 5 de junio,
+// though a date which already has its day only takes a number that counts,
+// so that 7 7 7 is not read as a single date. A number followed by a meridiem
+// such as 3 pm is a time of day.
+func (c *Classifier) looseNumber(i Iterator) bool {
+	if !isDigits(i.Current()) || c.date.month >= 0 {
+		return false
+	}
+
+	l := c.lang()
+	if _, _, err := l.ClassifyAsTimeOfDay(i); err == nil {
+		return false
+	}
+
+	for n := 1; ; n++ {
+		next, err := i.NextNth(n)
+		if err != nil {
+			return true
+		}
+
+		iterator := newTokenIterator([]string{next})
+		if _, err := l.ClassifyAsCommon(iterator); err == nil {
+			continue
+		}
+
+		if _, err := l.ClassifyAsInterval(iterator); err == nil || contains(l.Business, next) {
+			return false
+		}
+
+		if _, err := l.ClassifyAsMonth(iterator); err == nil && c.date.monthday == 0 {
+			return false
+		}
+
+		return true
+	}
+}
+
 func (c *Classifier) parseOffset(i Iterator) (int, error) {
-	if _, err := ClassifyAsCommon(i); err == nil {
+	if value, err := c.lang().ClassifyAsAhead(i); err == nil {
+		c.offset.direction = value
+		c.offset.size += 1
+		return 1, nil
+	}
+
+	if _, err := c.lang().ClassifyAsCommon(i); err == nil {
 		return 1, nil
 	}
 
-	if value, err := ClassifyAsDirection(i); err == nil {
+	if value, err := c.lang().ClassifyAsDirection(i); err == nil {
 		c.offset.direction = value
 		c.offset.size += 1
 		return 1, nil
 	}
 
-	if value, count, err := ClassifyAsIntegerStem(i); err == nil {
-		c.offset.count = value
+	if count, err := c.lang().ClassifyAsBusinessDay(i); err == nil {
+		c.offset.push()
+		c.offset.interval = INTERVAL_BUSINESS_DAY
+		c.offset.accurate(INTERVAL_BUSINESS_DAY)
 		c.offset.size += count
+		c.anchored = true
 		return count, nil
 	}
 
-	if value, err := ClassifyAsInterval(i); err == nil {
-		c.offset.interval = value
+	// a word such as "second" is both a number and an interval, directly
+	// after a count it is the interval
+	interval, intervalErr := c.lang().ClassifyAsInterval(i)
+	if intervalErr != nil || !c.followsCount(i) {
+		if value, count, err := c.lang().ClassifyAsIntegerStem(i); err == nil {
+			c.offset.push()
+			c.offset.count = value
+			c.offset.size += count
+			return count, nil
+		}
+	}
+
+	if intervalErr == nil {
+		c.offset.push()
+		c.offset.interval = interval
+		c.offset.accurate(interval)
 		c.offset.size += 1
+		c.anchored = true
 		return 1, nil
 	}
 
-	if value, err := ClassifyAsWeekday(i); err == nil {
-		c.offset.value = value
+	if values, err := c.lang().ClassifyAsWeekdayCandidates(i); err == nil {
+		c.offset.value = values[0]
 		c.offset.interval = INTERVAL_WEEKDAY
+		c.offset.accurate(INTERVAL_WEEKDAY)
 		c.offset.size += 1
+		c.anchored = true
+		if len(values) > 1 {
+			c.aliases = values
+			c.aliasedWord = i.Current()
+		}
 		return 1, nil
 	}
 
-	if value, err := ClassifyAsMonth(i); err == nil {
+	// the month of "2 days after june 1st" is part of the date that the
+	// offset is counted from
+	if value, err := c.lang().ClassifyAsMonth(i); err == nil && !c.offset.counted() {
 		c.offset.value = value
 		c.offset.interval = INTERVAL_MONTH
+		c.offset.accurate(INTERVAL_MONTH)
 		c.offset.size += 1
+		c.anchored = true
+		return 1, nil
+	}
+
+	return 1, newParseError(ErrUnrecognized, i, "offset")
+}
+
+func (c *Classifier) parseTime(i Iterator) (int, error) {
+	if value, count, err := c.lang().ClassifyAsTimeOfDay(i); err == nil {
+		c.clock.seconds = value
+		c.clock.size += count
+
+		// 2:30pm is precise to the minute and 14:30:15 to the second
+		switch strings.Count(i.Current(), ":") {
+		case 0:
+			c.clock.precision = INTERVAL_HOUR
+		case 1:
+			c.clock.precision = INTERVAL_MINUTE
+		default:
+			c.clock.precision = INTERVAL_SECOND
+		}
+		c.anchored = true
+		return count, nil
+	} else if errors.Is(err, ErrTimeOutOfRange) {
+		// a clock such as 0am or 13pm makes for an invalid time rather
+		// than a number that is picked up by the other contexts
+		if c.invalid == nil {
+			c.invalid = err
+		}
+		c.clock.size += 1
+		return 1, nil
+	}
+
+	return 1, newParseError(ErrUnrecognized, i, "time of day")
+}
+
+func (c *Classifier) parseSelector(i Iterator) (int, error) {
+	/*
+	   Parse the ordinal and unit of a day selected by its position inside
+	   of a period, along with the word that links it to the period. The
+	   period itself is left to the other contexts, so that both explicit
+	   and relative periods can be used:
+
+	           third wednesday of the month
+	           last friday of june
+	           first day of next month
+	           second to last weekend of the quarter
+	*/
+	ordinal, count, err := c.lang().ClassifyAsOrdinal(i)
+	if err != nil || c.date.ordinal != 0 {
+		return 1, newParseError(ErrUnrecognized, i, "ordinal")
+	}
+
+	word, err := i.NextNth(count)
+	if err != nil {
+		return 1, newParseError(ErrUnrecognized, i, "ordinal")
+	}
+
+	unit, weekday := -1, -1
+	iterator := newTokenIterator([]string{word})
+	if value, err := c.lang().ClassifyAsWeekday(iterator); err == nil {
+		unit, weekday = INTERVAL_WEEKDAY, value
+	} else if value, err := c.lang().ClassifyAsInterval(iterator); err == nil && (value == INTERVAL_DAY || value == INTERVAL_WEEK) {
+		unit = value
+	} else if days, err := c.lang().ClassifyAsDayGroup(iterator); err == nil && len(days) == 2 {
+		// a weekend is counted by its saturday
+		unit, weekday = INTERVAL_WEEKDAY, WEEKDAY_SATURDAY
+	} else {
+		return 1, newParseError(ErrUnrecognized, i, "ordinal")
+	}
+
+	link, err := i.NextNth(count + 1)
+	if err != nil || !contains(c.lang().Of, link) {
+		return 1, newParseError(ErrUnrecognized, i, "ordinal")
+	}
+
+	period, err := c.selectorPeriod(i, count+2)
+	if err != nil {
+		return 1, err
+	}
+
+	c.date.ordinal = ordinal
+	c.date.unit = unit
+	c.date.weekday = weekday
+	c.date.period = period
+	c.date.size += count + 2
+	c.anchored = true
+
+	return count + 2, nil
+}
+
+func (c *Classifier) parseBoundary(i Iterator) (int, error) {
+	/*
+	   Parse the start, middle or end of a period along with the word that
+	   links it to the period. As with selectors the period itself is left
+	   to the other contexts:
+
+	           end of the month
+	           start of next week
+	           middle of june
+	           eoy
+	*/
+	if c.date.ordinal != 0 || c.date.boundary >= 0 {
+		return 1, newParseError(ErrUnrecognized, i, "boundary")
+	}
+
+	if boundary, period, err := c.lang().ClassifyAsBoundaryAbbreviation(i); err == nil {
+		c.date.boundary = boundary
+		c.date.period = period
+		c.date.size += 1
+		c.anchored = true
+		return 1, nil
+	}
+
+	boundary, err := c.lang().ClassifyAsBoundary(i)
+	if err != nil {
+		return 1, err
+	}
+
+	// the linking word is left out in some languages, eg: ende juni
+	count := 1
+	if link, err := i.NextNth(1); err == nil && contains(c.lang().Of, link) {
+		count = 2
+	}
+
+	period, err := c.selectorPeriod(i, count)
+	if err != nil {
+		return 1, err
+	}
+
+	c.date.boundary = boundary
+	c.date.period = period
+	c.date.size += count
+	c.anchored = true
+
+	return count, nil
+}
+
+func (c *Classifier) parseFiscal(i Iterator) (int, error) {
+	/*
+	   Parse a quarter, half or fiscal year. A fiscal year without a
+	   number is counted as an interval, so that the direction of "next
+	   fiscal year" applies to it:
+
+	           Q3 2016
+	           FY2017 Q2
+	           next fiscal year
+	*/
+	if period, index, err := ClassifyAsFiscalPeriod(i); err == nil {
+		c.date.span = period
+		c.date.index = index
+		c.date.size += 1
+		c.anchored = true
 		return 1, nil
+	} else if errors.Is(err, ErrDayOutOfRange) {
+		// there is no fifth quarter, so q5 is an invalid date rather than
+		// a word to skip
+		if c.invalid == nil {
+			c.invalid = err
+		}
+		c.date.size += 1
+		return 1, nil
+	}
+
+	year, count, err := c.lang().ClassifyAsFiscalYear(i)
+	if err != nil {
+		return 1, err
+	}
+
+	// a quarter or half is narrower than the fiscal year it is written with
+	if c.date.span < 0 {
+		c.date.span = INTERVAL_FISCAL_YEAR
+	}
+
+	if year > 0 {
+		c.date.year = year
+	} else {
+		c.offset.push()
+		c.offset.interval = INTERVAL_YEAR
+		c.offset.accurate(INTERVAL_YEAR)
+		c.offset.size += count
+	}
+	c.date.size += count
+	c.anchored = true
+
+	return count, nil
+}
+
+func (c *Classifier) parseWeek(i Iterator) (int, error) {
+	/*
+	   Parse a week of the year or a week named by one of its days. An
+	   ISO week date which includes the day of the week is a single date:
+
+	           week 23
+	           W23 2016
+	           2016-W23-2
+	           the week of june 1st
+	           week ending friday june 5
+	*/
+	if year, week, day, err := ClassifyAsISOWeek(i); err == nil {
+		monday, err := isoWeek(year, week, time.UTC)
+		if err != nil {
+			c.invalid = newParseError(err, i, "iso week")
+			c.date.size += 1
+			return 1, nil
+		}
+
+		if day > 0 {
+			date := monday.AddDate(0, 0, day-1)
+			c.date.year = date.Year()
+			c.date.month = monthOrder[date.Month()-1]
+			c.date.monthday = date.Day()
+		} else {
+			c.date.span = INTERVAL_ISO_WEEK
+			c.date.year = year
+			c.date.week = week
+		}
+		c.date.size += 1
+		c.anchored = true
+		return 1, nil
+	} else if errors.Is(err, ErrDayOutOfRange) {
+		// a week or day that does not exist, such as 2016-w23-8, is an
+		// invalid date rather than a word to skip
+		if c.invalid == nil {
+			c.invalid = err
+		}
+		c.date.size += 1
+		return 1, nil
+	}
+
+	week, count, err := c.lang().ClassifyAsWeekNumber(i)
+	var parseErr *ParseError
+	switch {
+	case err == nil:
+		c.date.span = INTERVAL_ISO_WEEK
+		c.date.week = week
+		c.date.size += count
+		c.anchored = true
+		return count, nil
+	case errors.As(err, &parseErr) && errors.Is(parseErr, ErrDayOutOfRange):
+		// as is week 54, rather than a number that is picked up by the
+		// other contexts
+		if c.invalid == nil {
+			c.invalid = err
+		}
+		count = parseErr.Index - i.Index() + 1
+		c.date.size += count
+		return count, nil
+	}
+
+	ending, count, err := c.lang().ClassifyAsWeekOf(i)
+	if err != nil {
+		return 1, err
+	}
+
+	c.date.span = INTERVAL_ISO_WEEK
+	c.date.ending = ending
+	c.date.size += count
+	c.anchored = true
+
+	return count, nil
+}
+
+// fiscalShift returns the number of months that the fiscal year starts after
+// january
+func (c *Classifier) fiscalShift() int {
+	month, err := ConstantToMonth(c.fiscalStart)
+	if err != nil {
+		return 0
+	}
+
+	return int(month) - 1
+}
+
+// selectorPeriod returns the period named after the n-th word of the
+// iterator, skipping words such as "the" or "next"
+func (c *Classifier) selectorPeriod(i Iterator, n int) (int, error) {
+	for ; ; n++ {
+		word, err := i.NextNth(n)
+		if err != nil {
+			break
+		}
+
+		iterator := newTokenIterator([]string{word})
+		if _, err := c.lang().ClassifyAsCommon(iterator); err == nil {
+			continue
+		}
+
+		if _, err := c.lang().ClassifyAsDirection(iterator); err == nil {
+			continue
+		}
+
+		if interval, err := c.lang().ClassifyAsInterval(iterator); err == nil {
+			switch interval {
+			case INTERVAL_DAY, INTERVAL_WEEK, INTERVAL_MONTH, INTERVAL_QUARTER, INTERVAL_YEAR:
+				return interval, nil
+			}
+			break
+		}
+
+		if period, _, err := ClassifyAsFiscalPeriod(iterator); err == nil {
+			return period, nil
+		}
+
+		if contains(c.lang().FiscalYear, word) || contains(c.lang().Fiscal, word) {
+			return INTERVAL_FISCAL_YEAR, nil
+		}
+
+		// a single day such as tomorrow or friday
+		if _, err := c.lang().ClassifyAsDaySynonym(iterator); err == nil {
+			return INTERVAL_DAY, nil
+		}
+
+		if _, err := c.lang().ClassifyAsWeekday(iterator); err == nil {
+			return INTERVAL_DAY, nil
+		}
+
+		if c.monthWord([]string{word}) != "" {
+			return INTERVAL_MONTH, nil
+		}
+
+		if _, _, err := c.lang().ClassifyAsYear(iterator); err == nil {
+			return INTERVAL_YEAR, nil
+		}
+		break
 	}
 
-	return 1, errors.New("Unable to parse any valid pattern or value from iterator")
+	return 0, newParseError(ErrUnrecognized, i, "period")
 }
 
 func (c *Classifier) parseDate(i Iterator) (int, error) {
 	// this looks for arbitrary components of a date and attempts to parse
 	// them together into a dateContext which can be compiled and used as the starting point
-	if _, err := ClassifyAsCommon(i); err == nil {
+	if _, err := c.lang().ClassifyAsCommon(i); err == nil {
 		return 1, nil
 	}
 
-	if value, err := ClassifyAsDaySynonym(i); err == nil {
+	year, month, day, err := c.lang().ClassifyAsNumericDate(i, c.dateOrder())
+	if other, exists := reorder(c.dateOrder()); exists && errors.Is(err, ErrInvalidMonth) {
+		// a date whose month is only valid in the other order, such as
+		// 13/06 when reading month/day/year, is read in that order
+		if y, m, d, otherErr := c.lang().ClassifyAsNumericDate(i, other); otherErr == nil {
+			year, month, day, err = y, m, d, nil
+			c.misordered = true
+		}
+	}
+
+	if err == nil {
+		c.date.year = year
+		c.date.month = month
+		c.date.monthday = day
+		c.date.size += 1
+		c.monthday = newParseError(ErrDayOutOfRange, i, "day of the month")
+		c.anchored = true
+
+		if c.misordered {
+			c.reorderedWord = i.Current()
+		} else if other, exists := reorder(c.dateOrder()); exists {
+			year, month, day, err := c.lang().ClassifyAsNumericDate(i, other)
+			if err == nil && (month != c.date.month || day != c.date.monthday) {
+				c.reorderedWord = i.Current()
+				c.reordered = [3]int{year, month, day}
+			}
+		}
+		return 1, nil
+	}
+
+	if value, err := c.lang().ClassifyAsDaySynonym(i); err == nil {
 		c.date.synonym = value
 		c.date.size += 1
+		c.anchored = true
 		return 1, nil
 	}
 
-	if value, err := ClassifyAsWeekday(i); err == nil {
+	if holiday, count, err := c.calendar().ClassifyAsHoliday(i); err == nil {
+		c.date.holiday = &holiday
+		c.date.size += count
+		c.anchored = true
+		return count, nil
+	}
+
+	if value, err := c.lang().ClassifyAsWeekday(i); err == nil {
 		c.date.weekday = value
 		c.date.size += 1
 		return 1, nil
 	}
 
-	if value, err := ClassifyAsMonth(i); err == nil {
-		// here's an example of where this sort of thing breaks June
-		// 1st 2015... the second time around this will pick up the 1
-		// as a month :( Need to figure out a way to "partially"
-		// classify things
-		if c.date.month == 0 {
+	// a number on its own is never the month, months are only written as
+	// numbers inside of a numeric date such as 06/01/2015
+	if _, _, err := c.lang().ClassifyWordAsInteger(i.Current()); err != nil && c.date.month < 0 {
+		if value, err := c.lang().ClassifyAsMonth(i); err == nil {
 			c.date.month = value
 			c.date.size += 1
+			c.anchored = true
 			return 1, nil
 		}
 	}
 
-	if value, count, err := ClassifyAsDateday(i); err == nil {
+	// the interval of "1 second ago" is not the 2nd day of the month
+	if _, err := c.lang().ClassifyAsInterval(i); err == nil && c.followsCount(i) {
+		return 1, newParseError(ErrUnrecognized, i, "date")
+	}
+
+	value, count, dayErr := c.lang().ClassifyAsDateday(i)
+	if dayErr == nil && !c.countsInterval(i, count) {
 		c.date.monthday = value
 		c.date.size += 1
+		c.monthday = newParseError(ErrDayOutOfRange, i, "day of the month")
 		return count, nil
 	}
 
-	if value, count, err := ClassifyAsYear(i); err == nil {
+	if value, count, err := c.lang().ClassifyAsYear(i); err == nil {
 		c.date.year = value
 		c.date.size += 1
 		return count, nil
 	}
 
-	return 1, errors.New("Unable to parse any valid pattern or value from iterator")
+	// a number directly following the name of a month which can not be a
+	// day, such as june 45th, makes for an invalid date rather than noise
+	if errors.Is(dayErr, ErrDayOutOfRange) && c.invalid == nil {
+		if prev, err := i.Prev(); err == nil && c.monthWord([]string{prev}) != "" {
+			c.invalid = dayErr
+		}
+	}
+
+	return 1, newParseError(ErrUnrecognized, i, "date")
+}
+
+// countsInterval returns whether the number made up of the next count words
+// is followed by an interval, such as the 3 of "3 days ago", in which case it
+// is a count rather than a day of the month
+func (c *Classifier) countsInterval(i Iterator, count int) bool {
+	for n := count; ; n++ {
+		word, err := i.NextNth(n)
+		if err != nil {
+			return false
+		}
+
+		if !c.lang().ClassifyWordAsCommon(word) {
+			return c.lang().isInterval(word)
+		}
+	}
+}
+
+// followsCount returns whether the previous word is a number
+func (c *Classifier) followsCount(i Iterator) bool {
+	prev, err := i.Prev()
+	if err != nil {
+		return false
+	}
+
+	_, _, err = c.lang().ClassifyWordAsInteger(prev)
+	return err == nil
+}
+
+// reorder returns the order that an ambiguous numeric date could otherwise
+// have been written in
+func reorder(order int) (int, bool) {
+	switch order {
+	case ORDER_MDY:
+		return ORDER_DMY, true
+	case ORDER_DMY:
+		return ORDER_MDY, true
+	}
+
+	return order, false
 }
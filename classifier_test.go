@@ -1,10 +1,16 @@
 package datelp
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 )
 
+// the reference of most tests is a sunday so that weekday expressions are stable
+var sunday = time.Date(2015, time.May, 31, 12, 0, 0, 0, time.UTC)
+
 func TestClassifierOffsetContext(t *testing.T) {
 	testCases := []struct {
 		input  string
@@ -14,7 +20,8 @@ func TestClassifierOffsetContext(t *testing.T) {
 			interval:  INTERVAL_WEEK,
 			direction: DIRECTION_LEFT,
 			count:     3,
-			value:     0,
+			value:     -1,
+			truncate:  INTERVAL_WEEK,
 			size:      3,
 		}},
 		{"last wednesday", OffsetContext{
@@ -22,6 +29,7 @@ func TestClassifierOffsetContext(t *testing.T) {
 			direction: DIRECTION_LEFT,
 			count:     1,
 			value:     WEEKDAY_WEDNESDAY,
+			truncate:  INTERVAL_DAY,
 			size:      2,
 		}},
 		{"2 tuesdays ago", OffsetContext{
@@ -29,13 +37,15 @@ func TestClassifierOffsetContext(t *testing.T) {
 			direction: DIRECTION_LEFT,
 			count:     2,
 			value:     WEEKDAY_TUESDAY,
+			truncate:  INTERVAL_DAY,
 			size:      3,
 		}},
 		{"last month", OffsetContext{
 			interval:  INTERVAL_MONTH,
 			direction: DIRECTION_LEFT,
 			count:     1,
-			value:     0,
+			value:     -1,
+			truncate:  INTERVAL_MONTH,
 			size:      2,
 		}},
 		{"next june", OffsetContext{
@@ -43,6 +53,7 @@ func TestClassifierOffsetContext(t *testing.T) {
 			direction: DIRECTION_RIGHT,
 			count:     1,
 			value:     MONTH_JUNE,
+			truncate:  INTERVAL_MONTH,
 			size:      2,
 		}},
 		{"last july", OffsetContext{
@@ -50,18 +61,30 @@ func TestClassifierOffsetContext(t *testing.T) {
 			direction: DIRECTION_LEFT,
 			count:     1,
 			value:     MONTH_JULY,
+			truncate:  INTERVAL_MONTH,
 			size:      2,
 		}},
 		{"2 weeks from today", OffsetContext{
 			interval:  INTERVAL_WEEK,
 			direction: DIRECTION_RIGHT,
 			count:     2,
+			value:     -1,
+			truncate:  INTERVAL_WEEK,
 			size:      3,
 		}},
+		{"1 year 2 months and 3 days ago", OffsetContext{
+			interval:  INTERVAL_DAY,
+			direction: DIRECTION_LEFT,
+			count:     3,
+			value:     -1,
+			truncate:  INTERVAL_DAY,
+			size:      7,
+		}},
 		{"tuesday", OffsetContext{
 			interval:  INTERVAL_WEEKDAY,
 			direction: DIRECTION_CURRENT,
 			value:     WEEKDAY_TUESDAY,
+			truncate:  INTERVAL_DAY,
 			count:     1,
 			size:      1,
 		}},
@@ -113,11 +136,21 @@ func TestClassifierDateContext(t *testing.T) {
 			size:    1,
 			weekday: -1,
 			synonym: SYNONYM_TOMORROW,
+			month:   -1,
 		}},
 		{"day after tomorrow", DateContext{
 			size:    1,
 			weekday: -1,
 			synonym: SYNONYM_TOMORROW,
+			month:   -1,
+		}},
+		{"january 2 2016", DateContext{
+			size:     3,
+			monthday: 2,
+			month:    MONTH_JANUARY,
+			weekday:  -1,
+			year:     2016,
+			synonym:  -1,
 		}},
 	}
 
@@ -150,22 +183,97 @@ func TestClassifierDateContext(t *testing.T) {
 }
 
 func TestClassifierEndToEnd(t *testing.T) {
+	ref := sunday
+
 	testCases := []struct {
 		input    string
 		expected time.Time
 	}{
-		{"june 2nd", time.Date(time.Now().Year(), time.June, 2, 0, 0, 0, 0, time.UTC)},
-		{"day after tomorrow", time.Now().AddDate(0, 0, 2)},
-		{"day before yesterday", time.Now().AddDate(0, 0, -2)},
-		{"tuesday", time.Now().AddDate(0, 0, 2)},
-		{"next tuesday", time.Now().AddDate(0, 0, 9)},
-		{"this sunday", time.Now()},
-		{"next sunday", time.Now().AddDate(0, 0, 7)},
-		{"today", time.Now()},
+		{"june 2nd", time.Date(2015, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{"day after tomorrow", ref.AddDate(0, 0, 2)},
+		{"day before yesterday", ref.AddDate(0, 0, -2)},
+		{"tuesday", ref.AddDate(0, 0, 2)},
+		{"next tuesday", ref.AddDate(0, 0, 9)},
+		{"this sunday", ref},
+		{"next sunday", ref.AddDate(0, 0, 7)},
+		{"today", ref},
+		{"tomorrow at 3pm", time.Date(2015, time.June, 1, 15, 0, 0, 0, time.UTC)},
+		{"3 p.m.", time.Date(2015, time.May, 31, 15, 0, 0, 0, time.UTC)},
+		{"14:30", time.Date(2015, time.May, 31, 14, 30, 0, 0, time.UTC)},
+		{"noon tomorrow", time.Date(2015, time.June, 1, 12, 0, 0, 0, time.UTC)},
+		{"june 2nd at midnight", time.Date(2015, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{"next tuesday at 9 o'clock", time.Date(2015, time.June, 9, 9, 0, 0, 0, time.UTC)},
+		{"2015-06-01", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"06/01/2015 at 3pm", time.Date(2015, time.June, 1, 15, 0, 0, 0, time.UTC)},
+		{"1.6.2015", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"01-jun-2015", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"6/7", time.Date(2015, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{"3 days ago", ref.AddDate(0, 0, -3)},
+		{"1st june", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"Tuesday", ref.AddDate(0, 0, 2)},
+		{"June 1st, 2015.", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"(tomorrow)", ref.AddDate(0, 0, 1)},
+		{"3weeks ago", ref.AddDate(0, 0, -21)},
+		{"tomorrow at 10AM", time.Date(2015, time.June, 1, 10, 0, 0, 0, time.UTC)},
+		{"June1st", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"in 3 hours", ref.Add(3 * time.Hour)},
+		{"45 minutes ago", ref.Add(-45 * time.Minute)},
+		{"30 seconds from now", ref.Add(30 * time.Second)},
+		{"in 20 mins", ref.Add(20 * time.Minute)},
+		{"in an hour", ref.Add(time.Hour)},
+		{"2 hrs ago", ref.Add(-2 * time.Hour)},
+		{"1 second ago", ref.Add(-time.Second)},
+		{"30s ago", ref.Add(-30 * time.Second)},
+		{"in 10 sec", ref.Add(10 * time.Second)},
+		{"a century ago", ref.AddDate(-100, 0, 0)},
+		{"in june", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"last month", ref.AddDate(0, -1, 0)},
+		{"1 year 2 months and 3 days ago", ref.AddDate(-1, -2, -3)},
+		{"2 weeks and 3 days from now", ref.AddDate(0, 0, 17)},
+		{"3 days and 2 weeks from now", ref.AddDate(0, 0, 17)},
+		{"a year and a day ago", ref.AddDate(-1, 0, -1)},
+		{"in 1 hour 30 minutes", ref.Add(90 * time.Minute)},
+		{"2 days after june 1st", time.Date(2015, time.June, 3, 0, 0, 0, 0, time.UTC)},
+		{"third wednesday of the month", time.Date(2015, time.May, 20, 0, 0, 0, 0, time.UTC)},
+		{"last friday of june", time.Date(2015, time.June, 26, 0, 0, 0, 0, time.UTC)},
+		{"first day of next month", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"second to last day of the year", time.Date(2015, time.December, 30, 0, 0, 0, 0, time.UTC)},
+		{"first monday of the quarter", time.Date(2015, time.April, 6, 0, 0, 0, 0, time.UTC)},
+		{"2nd tuesday of july 2016 at 3pm", time.Date(2016, time.July, 12, 15, 0, 0, 0, time.UTC)},
+		{"last weekend of the month", time.Date(2015, time.May, 30, 0, 0, 0, 0, time.UTC)},
+		{"end of the month", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"end of next month", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"start of next week", time.Date(2015, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{"beginning of the year", time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"middle of the day", time.Date(2015, time.May, 31, 12, 0, 0, 0, time.UTC)},
+		{"middle of june", time.Date(2015, time.June, 16, 0, 0, 0, 0, time.UTC)},
+		{"end of the quarter", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"end of tomorrow", time.Date(2015, time.June, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"end of the month at 5pm", time.Date(2015, time.May, 31, 17, 0, 0, 0, time.UTC)},
+		{"EOY", time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"eom", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"end of 2016", time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"beginning of Q3", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of FY2016", time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{"first monday of Q4", time.Date(2015, time.October, 5, 0, 0, 0, 0, time.UTC)},
+		{"2016-W23-2", time.Date(2016, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{"2015W537", time.Date(2016, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"next week", ref.AddDate(0, 0, 7)},
+		{"christmas", time.Date(2015, time.December, 25, 0, 0, 0, 0, time.UTC)},
+		{"2 days before thanksgiving", time.Date(2015, time.November, 24, 0, 0, 0, 0, time.UTC)},
+		{"new year's eve at 11pm", time.Date(2015, time.December, 31, 23, 0, 0, 0, time.UTC)},
+		{"easter 2016", time.Date(2016, time.March, 27, 0, 0, 0, 0, time.UTC)},
+		{"the day after labor day", time.Date(2015, time.September, 8, 0, 0, 0, 0, time.UTC)},
+		{"memorial day", time.Date(2015, time.May, 25, 0, 0, 0, 0, time.UTC)},
+		{"in 3 business days", time.Date(2015, time.June, 3, 12, 0, 0, 0, time.UTC)},
+		{"5 working days ago", time.Date(2015, time.May, 25, 12, 0, 0, 0, time.UTC)},
+		{"next business day", time.Date(2015, time.June, 1, 12, 0, 0, 0, time.UTC)},
+		{"2 workdays after june 5th", time.Date(2015, time.June, 9, 0, 0, 0, 0, time.UTC)},
+		{"1 week and 2 business days from now", time.Date(2015, time.June, 9, 12, 0, 0, 0, time.UTC)},
 	}
 
 	for _, tc := range testCases {
-		c := NewClassifier()
+		c := NewClassifier(WithReference(ref))
 		i := newWordIterator(tc.input)
 		res, _ := c.Parse(i)
 
@@ -173,8 +281,384 @@ func TestClassifierEndToEnd(t *testing.T) {
 			t.Fatalf("Result object was nil.")
 		}
 
-		if tc.expected.Truncate(time.Hour*24) != res.Date.Truncate(time.Hour*24) {
+		if !tc.expected.Equal(res.Date) {
 			t.Fatalf("Did not convert \"%s\". Expected: %s Actual: %s", tc.input, tc.expected, res.Date)
 		}
 	}
 }
+
+func TestParseAt(t *testing.T) {
+	ref := sunday
+
+	actual, err := ParseAt("tomorrow", ref)
+	if err != nil {
+		t.Fatalf("Unexpected error returned: %s", err)
+	}
+
+	if expected := ref.AddDate(0, 0, 1); !actual.Equal(expected) {
+		t.Errorf("ParseAt failed. expected: %s actual: %s", expected, actual)
+	}
+}
+
+func TestClassifierBoundaryRanges(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		input string
+		start time.Time
+	}{
+		{"end of next month", time.Date(2015, time.June, 30, 0, 0, 0, 0, time.UTC)},
+		{"start of next week", time.Date(2015, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{"eoy", time.Date(2015, time.December, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref), WithBoundaryRanges())
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if res.Kind != KIND_RANGE || res.Range == nil {
+			t.Fatalf("Expected a range for \"%s\", got kind %d", tc.input, res.Kind)
+		}
+
+		if !res.Range.Start.Equal(tc.start) || !res.Range.End.Equal(tc.start.AddDate(0, 0, 1)) {
+			t.Errorf("Wrong range for \"%s\": %s - %s", tc.input, res.Range.Start, res.Range.End)
+		}
+	}
+}
+
+func TestClassifierFiscalRanges(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		input  string
+		start  time.Time
+		months int
+		fiscal int
+	}{
+		{"Q3", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_JANUARY},
+		{"Q3 2016", time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_JANUARY},
+		{"last quarter", time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_JANUARY},
+		{"next quarter", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_JANUARY},
+		{"this quarter", time.Date(2015, time.April, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_JANUARY},
+		{"next fiscal year", time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC), 12, MONTH_JANUARY},
+		{"FY17", time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 12, MONTH_JANUARY},
+		{"FY2017 Q2", time.Date(2017, time.April, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_JANUARY},
+		{"H1", time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC), 6, MONTH_JANUARY},
+		{"H2 2016", time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC), 6, MONTH_JANUARY},
+		{"FY17", time.Date(2016, time.October, 1, 0, 0, 0, 0, time.UTC), 12, MONTH_OCTOBER},
+		{"FY2017 Q2", time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_OCTOBER},
+		{"Q1", time.Date(2014, time.October, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_OCTOBER},
+		{"last quarter", time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC), 3, MONTH_OCTOBER},
+		{"this fiscal year", time.Date(2014, time.October, 1, 0, 0, 0, 0, time.UTC), 12, MONTH_OCTOBER},
+		{"last fiscal year", time.Date(2014, time.April, 1, 0, 0, 0, 0, time.UTC), 12, MONTH_APRIL},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref), WithFiscalYearStart(tc.fiscal))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if res.Kind != KIND_RANGE || res.Range == nil {
+			t.Fatalf("Expected a range for \"%s\", got kind %d", tc.input, res.Kind)
+		}
+
+		end := tc.start.AddDate(0, tc.months, 0)
+		if !res.Range.Start.Equal(tc.start) || !res.Range.End.Equal(end) || !res.Date.Equal(tc.start) {
+			t.Errorf("Wrong range for \"%s\". Expected: %s - %s Actual: %s - %s", tc.input, tc.start, end, res.Range.Start, res.Range.End)
+		}
+	}
+}
+
+func TestClassifierWeekRanges(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		input string
+		start time.Time
+	}{
+		{"week 23", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"W23 2016", time.Date(2016, time.June, 6, 0, 0, 0, 0, time.UTC)},
+		{"2016-W23", time.Date(2016, time.June, 6, 0, 0, 0, 0, time.UTC)},
+		{"week 1 2016", time.Date(2016, time.January, 4, 0, 0, 0, 0, time.UTC)},
+		{"the week of June 1st", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"the week of june 4th", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"week ending friday June 5", time.Date(2015, time.May, 30, 0, 0, 0, 0, time.UTC)},
+		{"week ending friday", time.Date(2015, time.May, 30, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if res.Kind != KIND_RANGE || res.Range == nil {
+			t.Fatalf("Expected a range for \"%s\", got kind %d", tc.input, res.Kind)
+		}
+
+		end := tc.start.AddDate(0, 0, 7)
+		if !res.Range.Start.Equal(tc.start) || !res.Range.End.Equal(end) {
+			t.Errorf("Wrong range for \"%s\". Expected: %s - %s Actual: %s - %s", tc.input, tc.start, end, res.Range.Start, res.Range.End)
+		}
+	}
+}
+
+func TestClassifierHolidays(t *testing.T) {
+	ref := sunday
+	calendar := NewHolidayCalendar(Holiday{[]string{"company offsite"}, WeekdayHoliday(2, WEEKDAY_FRIDAY, MONTH_JUNE)})
+
+	c := NewClassifier(WithReference(ref), WithHolidays(calendar))
+	res, err := c.Parse(newWordIterator("the week before the company offsite"))
+	if err != nil {
+		t.Fatalf("Unexpected error returned: %s", err)
+	}
+
+	if expected := time.Date(2015, time.June, 5, 0, 0, 0, 0, time.UTC); !res.Date.Equal(expected) {
+		t.Errorf("Custom holiday was not resolved. expected: %s actual: %s", expected, res.Date)
+	}
+
+	// only the holidays of the calendar are recognized
+	if _, err := c.Parse(newWordIterator("christmas")); err == nil {
+		t.Errorf("Expected an error for a holiday which is not in the calendar")
+	}
+}
+
+func TestClassifierBusinessCalendar(t *testing.T) {
+	ref := sunday
+	memorial, _ := Holidays.Lookup("memorial day")
+	calendar := BusinessCalendar{Holidays: NewHolidayCalendar(memorial)}
+
+	c := NewClassifier(WithReference(ref), WithBusinessCalendar(calendar))
+	res, err := c.Parse(newWordIterator("5 working days ago"))
+	if err != nil {
+		t.Fatalf("Unexpected error returned: %s", err)
+	}
+
+	if expected := time.Date(2015, time.May, 22, 12, 0, 0, 0, time.UTC); !res.Date.Equal(expected) {
+		t.Errorf("Holiday was not skipped. expected: %s actual: %s", expected, res.Date)
+	}
+}
+
+func TestClassifierDateOrder(t *testing.T) {
+	testCases := []struct {
+		order    int
+		input    string
+		expected time.Time
+	}{
+		{ORDER_MDY, "06/01/2015", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{ORDER_DMY, "06/01/2015", time.Date(2015, time.January, 6, 0, 0, 0, 0, time.UTC)},
+		{ORDER_YMD, "15/06/01", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{ORDER_DMY, "2015-06-01", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{ORDER_MDY, "1.6.2015", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{ORDER_MDY, "13/06/2015", time.Date(2015, time.June, 13, 0, 0, 0, 0, time.UTC)},
+		{ORDER_DMY, "06/13/2015", time.Date(2015, time.June, 13, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithDateOrder(tc.order))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if !tc.expected.Equal(res.Date) {
+			t.Errorf("Did not convert \"%s\". Expected: %s Actual: %s", tc.input, tc.expected, res.Date)
+		}
+	}
+}
+
+func TestClassifierLocation(t *testing.T) {
+	// 02:00 UTC on june 1st is still may 31st on the west coast
+	ref := time.Date(2015, time.June, 1, 2, 0, 0, 0, time.UTC)
+	pacific := time.FixedZone("PDT", -7*3600)
+
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"today", time.Date(2015, time.May, 31, 19, 0, 0, 0, pacific)},
+		{"tomorrow", time.Date(2015, time.June, 1, 19, 0, 0, 0, pacific)},
+		{"june 2nd", time.Date(2015, time.June, 2, 0, 0, 0, 0, pacific)},
+		{"monday", time.Date(2015, time.June, 1, 19, 0, 0, 0, pacific)},
+		{"tomorrow at 3pm", time.Date(2015, time.June, 1, 15, 0, 0, 0, pacific)},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref), WithLocation(pacific))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if !tc.expected.Equal(res.Date) || res.Date.Location() != pacific {
+			t.Errorf("Did not convert \"%s\". Expected: %s Actual: %s", tc.input, tc.expected, res.Date)
+		}
+	}
+}
+
+func TestClassifierPrecision(t *testing.T) {
+	ref := time.Date(2015, time.May, 27, 10, 30, 0, 0, time.UTC)
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2015, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		input     string
+		precision int
+		explicit  Components
+		start     time.Time
+		end       time.Time
+	}{
+		{"june", INTERVAL_MONTH, Components{Month: true}, day(time.June, 1), day(time.July, 1)},
+		{"june 2015", INTERVAL_MONTH, Components{Year: true, Month: true}, day(time.June, 1), day(time.July, 1)},
+		{"june 1st", INTERVAL_DAY, Components{Month: true, Day: true}, day(time.June, 1), day(time.June, 2)},
+		{"june 1st 2015", INTERVAL_DAY, Components{Year: true, Month: true, Day: true}, day(time.June, 1), day(time.June, 2)},
+		{"the 5th", INTERVAL_DAY, Components{Day: true}, day(time.May, 5), day(time.May, 6)},
+		{"tomorrow", INTERVAL_DAY, Components{Year: true, Month: true, Day: true}, day(time.May, 28), day(time.May, 29)},
+		{"tomorrow at 3pm", INTERVAL_HOUR, Components{Year: true, Month: true, Day: true, Hour: true}, day(time.May, 28).Add(15 * time.Hour), day(time.May, 28).Add(16 * time.Hour)},
+		{"2:30pm", INTERVAL_MINUTE, Components{Hour: true, Minute: true}, day(time.May, 27).Add(870 * time.Minute), day(time.May, 27).Add(871 * time.Minute)},
+		{"in 2 hours", INTERVAL_HOUR, Components{Year: true, Month: true, Day: true, Hour: true}, day(time.May, 27).Add(12 * time.Hour), day(time.May, 27).Add(13 * time.Hour)},
+		{"next tuesday", INTERVAL_DAY, Components{Year: true, Month: true, Day: true}, day(time.June, 2), day(time.June, 3)},
+		{"next month", INTERVAL_MONTH, Components{Year: true, Month: true}, day(time.June, 1), day(time.July, 1)},
+		{"next june", INTERVAL_MONTH, Components{Year: true, Month: true}, time.Date(2016, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{"3 weeks ago", INTERVAL_WEEK, Components{Year: true, Month: true}, day(time.May, 3), day(time.May, 10)},
+		{"2 days after june 1st", INTERVAL_DAY, Components{Month: true, Day: true}, day(time.June, 3), day(time.June, 4)},
+		{"second week of june", INTERVAL_WEEK, Components{Month: true}, day(time.June, 8), day(time.June, 15)},
+		{"christmas", INTERVAL_DAY, Components{Month: true, Day: true}, day(time.December, 25), day(time.December, 26)},
+		{"Q3", INTERVAL_QUARTER, Components{}, day(time.July, 1), day(time.October, 1)},
+		{"week 23", INTERVAL_WEEK, Components{}, day(time.June, 1), day(time.June, 8)},
+		{"june 1st to june 5th", INTERVAL_DAY, Components{Month: true, Day: true}, day(time.June, 1), day(time.June, 6).Add(-time.Nanosecond)},
+		{"beginning of Q3", INTERVAL_DAY, Components{Day: true}, day(time.July, 1), day(time.July, 2)},
+		{"2nd tuesday of q3", INTERVAL_DAY, Components{Day: true}, day(time.July, 14), day(time.July, 15)},
+		{"week ending june 5", INTERVAL_WEEK, Components{Month: true, Day: true}, day(time.May, 30), day(time.June, 6)},
+		{"week ending friday", INTERVAL_WEEK, Components{Year: true, Month: true}, day(time.May, 23), day(time.May, 30)},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if res.Precision != tc.precision {
+			t.Errorf("Wrong precision for \"%s\". Expected: %s Actual: %s", tc.input, IntervalName(tc.precision), IntervalName(res.Precision))
+		}
+
+		if res.Explicit != tc.explicit {
+			t.Errorf("Wrong explicit components for \"%s\". Expected: %+v Actual: %+v", tc.input, tc.explicit, res.Explicit)
+		}
+
+		if res.Period == nil || !res.Period.Start.Equal(tc.start) || !res.Period.End.Equal(tc.end) {
+			t.Errorf("Wrong period for \"%s\". Expected: %s - %s Actual: %+v", tc.input, tc.start, tc.end, res.Period)
+		}
+	}
+}
+
+func TestClassifierExtractFrom(t *testing.T) {
+	ref := sunday
+
+	testCases := []string{
+		"met on june 1st and again next tuesday",
+		"the day after tomorrow works",
+		"out from june 1 to june 5, back after",
+		"standup every tuesday at 9am sharp, (tomorrow) at  3pm!",
+		"bought 3 apples",
+		"",
+
+		// runs of words which are classified but never make a date are
+		// longer than the window of the stream
+		strings.Repeat("7 ", 80) + "x tomorrow",
+		"june" + strings.Repeat(" the", 100) + " x tomorrow",
+		strings.Repeat("next ", 100) + "x tomorrow",
+	}
+
+	for _, tc := range testCases {
+		expected := ExtractAt(tc, ref)
+		matches := make([]Match, 0)
+
+		err := NewClassifier(WithReference(ref)).ExtractFrom(strings.NewReader(tc), func(match Match) {
+			matches = append(matches, match)
+		})
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc, err)
+		}
+
+		if len(matches) != len(expected) {
+			t.Errorf("ExtractFrom \"%s\" found %d matches. expected: %d", tc, len(matches), len(expected))
+			continue
+		}
+
+		for index, match := range matches {
+			if match.Text != expected[index].Text || match.Start != expected[index].Start || match.End != expected[index].End || !match.Date.Equal(expected[index].Date) {
+				t.Errorf("ExtractFrom \"%s\" returned %+v. expected: %+v", tc, match, expected[index])
+			}
+		}
+	}
+}
+
+// repeatReader reads text count times over without holding all of it
+type repeatReader struct {
+	text  string
+	count int
+	pos   int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	if r.count == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.text[r.pos:])
+	r.pos += n
+	if r.pos == len(r.text) {
+		r.pos = 0
+		r.count--
+	}
+
+	return n, nil
+}
+
+func TestClassifierExtractFromLargeReader(t *testing.T) {
+	ref := sunday
+	line := "INFO request served, retry scheduled for tomorrow at 3pm by the worker pool\n"
+
+	count := 0
+	reader := &repeatReader{text: line, count: 5000}
+	err := NewClassifier(WithReference(ref)).ExtractFrom(reader, func(match Match) {
+		start := count*len(line) + strings.Index(line, "tomorrow")
+		if match.Text != "tomorrow at 3pm" || match.Start != start || match.End != start+len(match.Text) {
+			t.Fatalf("ExtractFrom returned wrong match %d: %q [%d:%d]", count, match.Text, match.Start, match.End)
+		}
+		count++
+	})
+
+	if err != nil || count != 5000 {
+		t.Errorf("ExtractFrom found %d matches (%v). expected: 5000", count, err)
+	}
+}
+
+func TestClassifierExtractFromError(t *testing.T) {
+	failure := errors.New("disk on fire")
+	reader := io.MultiReader(strings.NewReader("see you tomorrow "), &failingReader{failure})
+
+	matches := 0
+	err := NewClassifier().ExtractFrom(reader, func(match Match) { matches++ })
+	if err != failure || matches != 1 {
+		t.Errorf("ExtractFrom should return the error of the reader, got %v after %d matches", err, matches)
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,110 @@
+// Command datelp-server serves the datelp classifier over HTTP so that tools
+// written in other languages can parse dates without reimplementing it.
+//
+//	datelp-server [-addr 127.0.0.1:8080] [-allow-origin origin] [-tz zone] [-lang code]
+//
+// See datelp.Handler for the endpoints.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/jonmorehouse/datelp"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr, listenAndServe))
+}
+
+// listenAndServe serves handler on addr with timeouts, so that slow or idle
+// clients can not hold connections open forever
+func listenAndServe(addr string, handler http.Handler) error {
+	return newServer(addr, handler).ListenAndServe()
+}
+
+func newServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+}
+
+// run configures the server from args and hands it to serve, returning the
+// exit status
+func run(args []string, stderr io.Writer, serve func(string, http.Handler) error) int {
+	flags := flag.NewFlagSet("datelp-server", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	addr := flags.String("addr", "127.0.0.1:8080", "address to listen on")
+	origin := flags.String("allow-origin", "", "origin allowed to call the server from a browser, eg: chrome-extension://id or *")
+	zone := flags.String("tz", "", "IANA time zone of requests without a zone (default the local zone)")
+	code := flags.String("lang", "en", "ISO 639-1 code of the language of requests without a locale")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	handler, err := newHandler(*origin, *zone, *code)
+	if err != nil {
+		fmt.Fprintf(stderr, "datelp-server: %s\n", err)
+		return 2
+	}
+
+	fmt.Fprintf(stderr, "datelp-server: listening on %s\n", *addr)
+	if err := serve(*addr, handler); err != nil {
+		fmt.Fprintf(stderr, "datelp-server: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func newHandler(origin, zone, code string) (http.Handler, error) {
+	options := []datelp.Option{}
+
+	if zone != "" {
+		location, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -tz: %s", err)
+		}
+		options = append(options, datelp.WithLocation(location))
+	}
+
+	language, exists := datelp.LookupLanguage(code)
+	if !exists {
+		return nil, fmt.Errorf("unknown -lang %q", code)
+	}
+	options = append(options, datelp.WithLanguage(language))
+
+	handler := http.Handler(datelp.NewHandler(options...))
+	if origin != "" {
+		handler = allowOrigin(origin, handler)
+	}
+
+	return handler, nil
+}
+
+// allowOrigin lets browsers call handler from origin, answering the preflight
+// request that they send before a JSON POST
+func allowOrigin(origin string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		status int
+	}{
+		{[]string{}, 0},
+		{[]string{"-tz", "Mars/Olympus"}, 2},
+		{[]string{"-lang", "xx"}, 2},
+		{[]string{"-unknown"}, 2},
+	}
+
+	for _, tc := range testCases {
+		served := ""
+		status := run(tc.args, &bytes.Buffer{}, func(addr string, handler http.Handler) error {
+			served = addr
+			return nil
+		})
+
+		if status != tc.status {
+			t.Errorf("%v returned the wrong status. expected: %d actual: %d", tc.args, tc.status, status)
+		}
+
+		if status == 0 && served != "127.0.0.1:8080" {
+			t.Errorf("Served on the wrong address: %q", served)
+		}
+	}
+
+	status := run([]string{}, &bytes.Buffer{}, func(string, http.Handler) error {
+		return errors.New("address in use")
+	})
+	if status != 1 {
+		t.Errorf("Expected a failure to serve to exit with 1, got %d", status)
+	}
+}
+
+func TestNewServer(t *testing.T) {
+	server := newServer("127.0.0.1:8080", http.NotFoundHandler())
+	if server.Addr != "127.0.0.1:8080" || server.Handler == nil {
+		t.Errorf("Server was not configured: %q %v", server.Addr, server.Handler)
+	}
+
+	if server.ReadHeaderTimeout <= 0 || server.ReadTimeout <= 0 || server.WriteTimeout <= 0 || server.IdleTimeout <= 0 {
+		t.Errorf("Server is missing timeouts: %+v", server)
+	}
+}
+
+func TestAllowOrigin(t *testing.T) {
+	handler, err := newHandler("chrome-extension://abc", "UTC", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("OPTIONS", "/parse", nil))
+	if recorder.Code != http.StatusNoContent || recorder.Header().Get("Access-Control-Allow-Origin") != "chrome-extension://abc" {
+		t.Errorf("Wrong preflight response: %d %v", recorder.Code, recorder.Header())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/parse", strings.NewReader(`{"text": "tomorrow"}`)))
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Errorf("Wrong response: %d %s", recorder.Code, recorder.Body.String())
+	}
+}
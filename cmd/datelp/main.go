@@ -0,0 +1,241 @@
+// Command datelp parses natural language dates from the command line.
+//
+//	datelp [flags] [phrase ...]
+//	datelp extract [flags] [file ...]
+//
+// Without a subcommand every argument, or every line of stdin when there are
+// none, is parsed as a single date. The extract subcommand prints every date
+// mentioned in the given files, or in stdin when there are none.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jonmorehouse/datelp"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// config holds the flags shared by every subcommand
+type config struct {
+	ref      string
+	zone     string
+	language string
+	format   string
+	strict   bool
+}
+
+// output is a single line of JSON output
+type output struct {
+	Input     string     `json:"input,omitempty"`
+	File      string     `json:"file,omitempty"`
+	Text      string     `json:"text,omitempty"`
+	Start     *int       `json:"start,omitempty"`
+	End       *int       `json:"end,omitempty"`
+	Kind      string     `json:"kind,omitempty"`
+	Date      *time.Time `json:"date,omitempty"`
+	Range     *[2]string `json:"range,omitempty"`
+	Precision string     `json:"precision,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// run executes the command and returns its exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	extract := len(args) > 0 && args[0] == "extract"
+	if extract {
+		args = args[1:]
+	}
+
+	flags := flag.NewFlagSet("datelp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: datelp [flags] [phrase ...]\n       datelp extract [flags] [file ...]\n\n")
+		flags.PrintDefaults()
+	}
+
+	cfg := config{}
+	flags.StringVar(&cfg.ref, "ref", "", "reference time that relative dates are resolved against, in RFC 3339 (default now)")
+	flags.StringVar(&cfg.zone, "tz", "", "IANA time zone that dates are computed in, eg: Europe/Paris (default the zone of -ref)")
+	flags.StringVar(&cfg.language, "lang", "en", "ISO 639-1 code of the language to parse")
+	flags.StringVar(&cfg.format, "format", "iso", "output format: iso, json or a Go time layout such as 2006-01-02")
+	flags.BoolVar(&cfg.strict, "strict", false, "require every word of a phrase to be part of the date")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	options, err := cfg.options()
+	if err != nil {
+		fmt.Fprintf(stderr, "datelp: %s\n", err)
+		return 2
+	}
+
+	if extract {
+		return runExtract(flags.Args(), stdin, stdout, stderr, cfg, options)
+	}
+
+	return runParse(flags.Args(), stdin, stdout, stderr, cfg, options)
+}
+
+// options turns the flags into classifier options
+func (cfg config) options() ([]datelp.Option, error) {
+	ref := time.Now()
+	if cfg.ref != "" {
+		parsed, err := time.Parse(time.RFC3339, cfg.ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -ref: %s", err)
+		}
+		ref = parsed
+	}
+
+	options := []datelp.Option{datelp.WithReference(ref)}
+
+	if cfg.zone != "" {
+		location, err := time.LoadLocation(cfg.zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -tz: %s", err)
+		}
+		options = append(options, datelp.WithLocation(location))
+	}
+
+	language, exists := datelp.LookupLanguage(cfg.language)
+	if !exists {
+		return nil, fmt.Errorf("unknown -lang %q", cfg.language)
+	}
+	options = append(options, datelp.WithLanguage(language))
+
+	if cfg.strict {
+		options = append(options, datelp.WithStrict())
+	}
+
+	return options, nil
+}
+
+func runParse(phrases []string, stdin io.Reader, stdout, stderr io.Writer, cfg config, options []datelp.Option) int {
+	if len(phrases) == 0 {
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				phrases = append(phrases, line)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			fmt.Fprintf(stderr, "datelp: %s\n", err)
+			return 1
+		}
+	}
+
+	status := 0
+	for _, phrase := range phrases {
+		classifier := datelp.NewClassifier(options...)
+		result, err := classifier.Parse(datelp.NewWordIterator(strings.NewReader(phrase)))
+		if err != nil {
+			status = 1
+		}
+
+		if cfg.format == "json" {
+			line := output{Input: phrase}
+			if err != nil {
+				line.Error = err.Error()
+			} else {
+				line.describe(*result)
+			}
+			writeJSON(stdout, line)
+			continue
+		}
+
+		if err != nil {
+			fmt.Fprintf(stderr, "datelp: %q: %s\n", phrase, err)
+			continue
+		}
+
+		fmt.Fprintln(stdout, format(*result, cfg.format))
+	}
+
+	return status
+}
+
+func runExtract(files []string, stdin io.Reader, stdout, stderr io.Writer, cfg config, options []datelp.Option) int {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := 0
+	for _, file := range files {
+		if err := extractFile(file, stdin, stdout, cfg, options); err != nil {
+			fmt.Fprintf(stderr, "datelp: %s\n", err)
+			status = 1
+		}
+	}
+
+	return status
+}
+
+// extractFile prints the dates mentioned in a file, or in stdin for "-", as
+// they are read
+func extractFile(file string, stdin io.Reader, stdout io.Writer, cfg config, options []datelp.Option) error {
+	input := stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	classifier := datelp.NewClassifier(options...)
+	return classifier.ExtractFrom(input, func(match datelp.Match) {
+		if cfg.format == "json" {
+			start, end := match.Start, match.End
+			line := output{File: file, Text: match.Text, Start: &start, End: &end}
+			line.describe(match.Result)
+			writeJSON(stdout, line)
+			return
+		}
+
+		fmt.Fprintf(stdout, "%s:%d:%d\t%s\t%s\n", file, match.Start, match.End, match.Text, format(match.Result, cfg.format))
+	})
+}
+
+// describe fills in the parts of the output which come from a result
+func (o *output) describe(result datelp.Result) {
+	date := result.Date
+	o.Date = &date
+	o.Kind = datelp.KindName(result.Kind)
+	o.Precision = datelp.IntervalName(result.Precision)
+
+	if result.Range != nil {
+		o.Range = &[2]string{result.Range.Start.Format(time.RFC3339), result.Range.End.Format(time.RFC3339)}
+	}
+}
+
+// format renders a result in ISO 8601 or a Go time layout. Ranges are written
+// as their start and end separated by a slash, like an ISO 8601 interval.
+func format(result datelp.Result, layout string) string {
+	if layout == "iso" {
+		layout = time.RFC3339
+	}
+
+	if result.Range != nil {
+		return result.Range.Start.Format(layout) + "/" + result.Range.End.Format(layout)
+	}
+
+	return result.Date.Format(layout)
+}
+
+func writeJSON(w io.Writer, line output) {
+	// every field of the output can be marshalled
+	data, _ := json.Marshal(line)
+	fmt.Fprintln(w, string(data))
+}
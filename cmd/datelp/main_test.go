@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const ref = "2015-05-31T12:00:00Z"
+
+func TestRunParse(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		stdin    string
+		expected string
+		status   int
+	}{
+		{[]string{"-ref", ref, "next tuesday"}, "", "2015-06-09T12:00:00Z\n", 0},
+		{[]string{"-ref", ref}, "tomorrow\n\njune 2nd\n", "2015-06-01T12:00:00Z\n2015-06-02T00:00:00Z\n", 0},
+		{[]string{"-ref", ref, "-format", "2006-01-02", "june 2nd"}, "", "2015-06-02\n", 0},
+		{[]string{"-ref", ref, "-tz", "Asia/Tokyo", "tomorrow"}, "", "2015-06-01T21:00:00+09:00\n", 0},
+		{[]string{"-ref", ref, "-lang", "es", "mañana"}, "", "2015-06-01T12:00:00Z\n", 0},
+		{[]string{"-ref", ref, "Q3"}, "", "2015-07-01T00:00:00Z/2015-10-01T00:00:00Z\n", 0},
+		{[]string{"-ref", ref, "hello"}, "", "", 1},
+		{[]string{"-ref", ref, "-strict", "june 2nd please"}, "", "", 1},
+		{[]string{"-ref", ref, "june 2nd please"}, "", "2015-06-02T00:00:00Z\n", 0},
+		{[]string{"-ref", "yesterday", "june 2nd"}, "", "", 2},
+	}
+
+	for _, tc := range testCases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		status := run(tc.args, strings.NewReader(tc.stdin), stdout, stderr)
+
+		if status != tc.status || stdout.String() != tc.expected {
+			t.Errorf("%q failed. expected: %q (%d) actual: %q (%d) stderr: %s", tc.args, tc.expected, tc.status, stdout.String(), status, stderr.String())
+		}
+	}
+}
+
+func TestRunParseJSON(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	status := run([]string{"-ref", ref, "-format", "json", "tomorrow", "blah"}, nil, stdout, stderr)
+	if status != 1 {
+		t.Errorf("Expected a failed phrase to set the exit status, got %d", status)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected a line per phrase, got: %q", stdout.String())
+	}
+
+	var parsed, failed output
+	if err := json.Unmarshal([]byte(lines[0]), &parsed); err != nil {
+		t.Fatalf("Invalid JSON %q: %s", lines[0], err)
+	}
+
+	if parsed.Input != "tomorrow" || parsed.Kind != "date" || parsed.Precision != "day" || parsed.Date == nil || parsed.Date.Day() != 1 {
+		t.Errorf("Wrong JSON for a parsed phrase: %s", lines[0])
+	}
+
+	if err := json.Unmarshal([]byte(lines[1]), &failed); err != nil || failed.Error == "" || failed.Date != nil {
+		t.Errorf("Wrong JSON for a failed phrase: %s", lines[1])
+	}
+}
+
+func TestRunExtract(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datelp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "notes.txt")
+	if err := ioutil.WriteFile(file, []byte("met on june 1st and again next tuesday"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	status := run([]string{"extract", "-ref", ref, "-format", "2006-01-02", file}, nil, stdout, stderr)
+
+	expected := file + ":7:15\tjune 1st\t2015-06-01\n" + file + ":26:38\tnext tuesday\t2015-06-09\n"
+	if status != 0 || stdout.String() != expected {
+		t.Errorf("extract failed. expected: %q actual: %q stderr: %s", expected, stdout.String(), stderr.String())
+	}
+
+	stdout.Reset()
+	if status := run([]string{"extract", filepath.Join(dir, "missing.txt")}, nil, stdout, stderr); status != 1 {
+		t.Errorf("Expected a missing file to fail, got %d", status)
+	}
+}
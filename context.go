@@ -1,7 +1,6 @@
 package datelp
 
 import (
-	"errors"
 	"math"
 	"time"
 )
@@ -19,8 +18,56 @@ const (
 	INTERVAL_MONTH
 	INTERVAL_YEAR
 	INTERVAL_CENTURY
+	INTERVAL_FORTNIGHT
+	INTERVAL_HOUR
+	INTERVAL_MINUTE
+	INTERVAL_SECOND
+	INTERVAL_QUARTER
+	INTERVAL_HALF
+	INTERVAL_FISCAL_YEAR
+	INTERVAL_ISO_WEEK
+	INTERVAL_BUSINESS_DAY
 )
 
+// IntervalName returns the lower case name of one of the INTERVAL constants,
+// such as "business_day" for INTERVAL_BUSINESS_DAY
+func IntervalName(interval int) string {
+	switch interval {
+	case INTERVAL_DAY:
+		return "day"
+	case INTERVAL_WEEKDAY:
+		return "weekday"
+	case INTERVAL_WEEK:
+		return "week"
+	case INTERVAL_MONTH:
+		return "month"
+	case INTERVAL_YEAR:
+		return "year"
+	case INTERVAL_CENTURY:
+		return "century"
+	case INTERVAL_FORTNIGHT:
+		return "fortnight"
+	case INTERVAL_HOUR:
+		return "hour"
+	case INTERVAL_MINUTE:
+		return "minute"
+	case INTERVAL_SECOND:
+		return "second"
+	case INTERVAL_QUARTER:
+		return "quarter"
+	case INTERVAL_HALF:
+		return "half"
+	case INTERVAL_FISCAL_YEAR:
+		return "fiscal_year"
+	case INTERVAL_ISO_WEEK:
+		return "iso_week"
+	case INTERVAL_BUSINESS_DAY:
+		return "business_day"
+	}
+
+	return ""
+}
+
 const (
 	SYNONYM_TODAY = iota << 1
 	SYNONYM_TOMORROW
@@ -52,16 +99,66 @@ const (
 	MONTH_DECEMBER
 )
 
+// the position of an instant inside of a period, such as the end of the month
+const (
+	BOUNDARY_START = iota << 1
+	BOUNDARY_MIDDLE
+	BOUNDARY_END
+)
+
+// the order that the day, month and year of a numeric date such as 06/01/2015
+// are written in
+const (
+	ORDER_MDY = iota << 1
+	ORDER_DMY
+	ORDER_YMD
+)
+
 type OffsetContext struct {
 	interval  int // day, month, week year
 	direction int // previous,after
 	count     int // how large of an offset (in terms of quantity) eg: 2 weeks
 	value     int // offset based upon a value instead of an interval. eg: next tuesday instead of next week
-	truncate  int // useful for cases when the value is only supposed to be percieved as accurate to a certain interval
+	truncate  int // the finest interval that the offset is accurate to, eg: INTERVAL_DAY for 1 year and 3 days
 	size      int //number of successful elements that the offset found
+
+	// decides which days are counted by INTERVAL_BUSINESS_DAY, the
+	// default business calendar when nil
+	calendar *BusinessCalendar
+
+	// the earlier terms of a compound offset such as 1 year 2 months and
+	// 3 days, the last of which is held by count and interval
+	terms []offsetTerm
+}
+
+type offsetTerm struct {
+	count    int
+	interval int
+}
+
+// accurate narrows the interval that the offset is accurate to when interval
+// is finer than it, so that 1 year and 3 days is accurate to the day
+func (oc *OffsetContext) accurate(interval int) {
+	oc.truncate = finer(oc.truncate, precisionOf(interval))
+}
+
+// counted returns whether a count of an interval, such as 2 days, was found
+func (oc *OffsetContext) counted() bool {
+	return len(oc.terms) > 0 || (oc.interval >= 0 && oc.value < 0)
+}
+
+// push moves the count and interval to the terms of a compound offset once
+// both of them were found, so that the next term can be collected
+func (oc *OffsetContext) push() {
+	if oc.interval < 0 || oc.value >= 0 {
+		return
+	}
+
+	oc.terms = append(oc.terms, offsetTerm{count: oc.count, interval: oc.interval})
+	oc.count = 1
+	oc.interval = -1
 }
 
-// TODO update this to support passing in a start date
 func (oc OffsetContext) Compile(origin time.Time) (time.Time, error) {
 	// by default we assume that if any value is specified, then this is a
 	// value Classification and treat it as such
@@ -96,7 +193,7 @@ func (oc OffsetContext) valueOffset(origin time.Time) (time.Time, error) {
 	   4. apply offset
 	*/
 	if oc.interval != INTERVAL_WEEKDAY && oc.interval != INTERVAL_MONTH {
-		return time.Now(), errors.New("Unable to parse as value offset")
+		return origin, ErrInvalidOffset
 	}
 
 	// figure out a delta which will correspond to the closest version of
@@ -149,31 +246,36 @@ func (oc OffsetContext) valueOffset(origin time.Time) (time.Time, error) {
 }
 
 func (oc OffsetContext) offset(origin time.Time) (time.Time, error) {
-	var days, months, years int
-
-	switch oc.interval {
-	case INTERVAL_WEEK:
-		days = oc.count * 7
-	case INTERVAL_DAY:
-		days = oc.count
-	case INTERVAL_MONTH:
-		months = oc.count
-	case INTERVAL_YEAR:
-		years = oc.count
+	// the terms are summed up before the direction is applied to all of
+	// them at once. Intervals that can not be counted, such as a weekday
+	// without a value, leave the origin as it is.
+	// Business days are counted once the rest of the offset was applied,
+	// since they depend on the day that they are counted from.
+	duration := Duration{}
+	business := 0
+	for _, term := range append(oc.terms, offsetTerm{count: oc.count, interval: oc.interval}) {
+		if term.interval == INTERVAL_BUSINESS_DAY {
+			business += term.count
+			continue
+		}
+		duration.addInterval(term.interval, term.count)
 	}
 
-	var direction int
 	switch oc.direction {
 	case DIRECTION_LEFT:
-		direction = -1
-	case DIRECTION_RIGHT:
-		direction = 1
+		duration = duration.Negate()
+		business = -business
 	case DIRECTION_CURRENT:
-		direction = 0
+		duration = Duration{}
+		business = 0
 	}
 
-	d := origin.AddDate(direction*years, direction*months, direction*days)
-	return d, nil
+	calendar := DefaultBusinessCalendar
+	if oc.calendar != nil {
+		calendar = *oc.calendar
+	}
+
+	return calendar.AddBusinessDays(duration.AddTo(origin), business), nil
 }
 
 type DateContext struct {
@@ -183,22 +285,51 @@ type DateContext struct {
 	month    int // eg MONTH_JUNE (constant)
 	monthday int // 0-31 day
 	year     int // year such as 2015
+
+	ordinal int // position of a day inside of a period, negative from the end. eg: -1 for the last friday of june
+	unit    int // what the ordinal counts, eg INTERVAL_WEEKDAY (constant)
+	period  int // the period that the ordinal counts inside of, eg INTERVAL_MONTH (constant)
+
+	boundary int // position inside of the period instead of an ordinal, eg BOUNDARY_END (constant)
+
+	span   int // fiscal period that the date names, eg INTERVAL_QUARTER (constant)
+	index  int // which of the spans inside of the fiscal year, eg: 3 for Q3
+	fiscal int // number of months that the fiscal year starts after january
+
+	holiday *Holiday // a named day such as christmas
+
+	week   int  // ISO week of the year, eg: 23 for W23
+	ending bool // whether the week ends on the date rather than containing it, eg: week ending friday
 }
 
-func (dc DateContext) Compile() (time.Time, error) {
+func (dc DateContext) Compile(origin time.Time) (time.Time, error) {
 	if dc.synonym >= 0 {
-		return dc.compileSynonym()
+		return dc.compileSynonym(origin)
+	}
+
+	if dc.holiday != nil {
+		return dc.compileHoliday(origin)
+	}
+
+	return dc.compile(origin)
+}
+
+// compileHoliday returns the holiday in the year of the date, or the year of
+// origin when no year was written
+func (dc DateContext) compileHoliday(origin time.Time) (time.Time, error) {
+	year := dc.year
+	if year < 1 {
+		year = origin.Year()
 	}
 
-	return dc.compile()
+	return dc.holiday.In(year, origin.Location()), nil
 }
 
-func (dc DateContext) compileSynonym() (time.Time, error) {
+func (dc DateContext) compileSynonym(origin time.Time) (time.Time, error) {
 	// TODO: figure out a way to make this more aligned with the work that
 	// we're doing in the OffsetContext. It could be argued that this isn't
 	// really a context since the synonyms only apply to a single day and
 	// are extremely specialized.
-	start := time.Now()
 	dayOffset := 0
 
 	switch {
@@ -209,17 +340,135 @@ func (dc DateContext) compileSynonym() (time.Time, error) {
 	case dc.synonym == SYNONYM_TOMORROW:
 		dayOffset = 1
 	default:
-		return time.Now(), errors.New("Invalid day synonym")
+		return origin, ErrInvalidSynonym
 	}
 
-	return start.AddDate(0, 0, dayOffset), nil
+	return origin.AddDate(0, 0, dayOffset), nil
 }
 
-func (dc DateContext) compileRelative() (time.Time, error) {
-	// TODO: support value based dates, such as 3rd week of december or 3rd
-	// Monday of the month.
+func (dc DateContext) compileRelative(origin time.Time) (time.Time, error) {
+	/*
+	   Select a day by its position inside of the period that origin falls
+	   in, such as the 3rd wednesday of the month or the last day of the
+	   year. Weeks are counted in steps of 7 days from the start of the
+	   period, so that the first week of june starts on june 1st.
+	*/
+	start := fiscalPeriodStart(origin, dc.period, dc.fiscal)
+	end := periodEnd(start, dc.period)
 
-	return time.Now(), nil
+	step := 1
+	if dc.unit == INTERVAL_WEEK {
+		step = 7
+	}
+
+	weekday, err := ConstantToWeekday(dc.weekday)
+	days := make([]time.Time, 0)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, step) {
+		if dc.unit == INTERVAL_WEEKDAY && (err != nil || day.Weekday() != weekday) {
+			continue
+		}
+		days = append(days, day)
+	}
+
+	index := dc.ordinal - 1
+	if dc.ordinal < 0 {
+		index = len(days) + dc.ordinal
+	}
+
+	if dc.ordinal == 0 || index < 0 || index >= len(days) {
+		return origin, ErrDayOutOfRange
+	}
+
+	return days[index], nil
+}
+
+func (dc DateContext) compileBoundary(origin time.Time) (time.Time, error) {
+	/*
+	   Return the instant at the start, middle or end of the period that
+	   origin falls in. The end of a period is its last instant, so that
+	   the end of june is still in june:
+
+	           start of next week => sunday 00:00
+	           middle of the day => 12:00
+	           end of the month => june 30th 23:59:59.999999999
+	*/
+	start := fiscalPeriodStart(origin, dc.period, dc.fiscal)
+	end := periodEnd(start, dc.period)
+
+	switch dc.boundary {
+	case BOUNDARY_START:
+		return start, nil
+	case BOUNDARY_MIDDLE:
+		return start.Add(end.Sub(start) / 2), nil
+	case BOUNDARY_END:
+		return end.Add(-time.Nanosecond), nil
+	}
+
+	return origin, ErrInvalidBoundary
+}
+
+func (dc DateContext) compileSpan(origin time.Time) (time.Time, error) {
+	/*
+	   Return the start of the fiscal period that the date names. The
+	   period is picked out of the fiscal year written with it, or the
+	   fiscal year that origin falls in. Fiscal years are named after the
+	   calendar year that they end in:
+
+	           Q3 => july 1st of this year
+	           FY2017 Q2 => april 1st 2017
+	           H2 2016 => july 1st 2016
+	*/
+	if dc.span == INTERVAL_ISO_WEEK {
+		return dc.compileWeek(origin)
+	}
+
+	if dc.year > 0 {
+		origin = fiscalYear(dc.year, dc.fiscal, origin.Location())
+	}
+
+	if dc.index < 1 {
+		return fiscalPeriodStart(origin, dc.span, dc.fiscal), nil
+	}
+
+	months := 0
+	switch dc.span {
+	case INTERVAL_QUARTER:
+		months = 3
+	case INTERVAL_HALF:
+		months = 6
+	}
+
+	if months == 0 || dc.index > 12/months {
+		return origin, ErrInvalidOffset
+	}
+
+	start := fiscalPeriodStart(origin, INTERVAL_FISCAL_YEAR, dc.fiscal)
+	return start.AddDate(0, months*(dc.index-1), 0), nil
+}
+
+func (dc DateContext) compileWeek(origin time.Time) (time.Time, error) {
+	/*
+	   Return a day inside of the week that the date names, either by its
+	   ISO week number or by a day of the week:
+
+	           week 23 => monday of week 23 of this year
+	           W23 2016 => monday june 6th 2016
+	           the week of june 1st => june 1st
+	*/
+	if dc.week > 0 {
+		year := dc.year
+		if year < 1 {
+			year, _ = origin.ISOWeek()
+		}
+
+		return isoWeek(year, dc.week, origin.Location())
+	}
+
+	if dc.synonym >= 0 || dc.holiday != nil || dc.month >= 0 || dc.monthday > 0 || dc.year > 0 {
+		return dc.Compile(origin)
+	}
+
+	return origin, nil
 }
 
 func (dc DateContext) isValid() bool {
@@ -235,18 +484,18 @@ func (dc DateContext) isValid() bool {
 	return true
 }
 
-func (dc DateContext) compile() (time.Time, error) {
+func (dc DateContext) compile(origin time.Time) (time.Time, error) {
 	var year, day int
 	var month time.Month
 
 	year = dc.year
 	if year < 1 {
-		year = time.Now().Year()
+		year = origin.Year()
 	}
 
 	month, err := ConstantToMonth(dc.month)
 	if err != nil {
-		month = time.Now().Month()
+		month = origin.Month()
 	}
 
 	day = dc.monthday
@@ -254,6 +503,179 @@ func (dc DateContext) compile() (time.Time, error) {
 		day = 1
 	}
 
-	compiledDate := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if day > time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+		return origin, ErrDayOutOfRange
+	}
+
+	compiledDate := time.Date(year, month, day, 0, 0, 0, 0, origin.Location())
 	return compiledDate, nil
 }
+
+// precisionOrder ranks the intervals that a date can be precise to, from the
+// coarsest to the finest
+var precisionOrder = []int{
+	INTERVAL_YEAR, INTERVAL_FISCAL_YEAR, INTERVAL_HALF, INTERVAL_QUARTER,
+	INTERVAL_MONTH, INTERVAL_WEEK, INTERVAL_ISO_WEEK, INTERVAL_DAY,
+	INTERVAL_HOUR, INTERVAL_MINUTE, INTERVAL_SECOND,
+}
+
+// precisionOf returns the interval that a count of interval is accurate to,
+// such as INTERVAL_DAY for 3 business days
+func precisionOf(interval int) int {
+	switch interval {
+	case INTERVAL_WEEKDAY, INTERVAL_BUSINESS_DAY:
+		return INTERVAL_DAY
+	case INTERVAL_FORTNIGHT:
+		return INTERVAL_WEEK
+	case INTERVAL_CENTURY:
+		return INTERVAL_YEAR
+	}
+
+	return interval
+}
+
+// finer returns whichever of two precisions is the finest, where a negative
+// precision is coarser than any other
+func finer(a, b int) int {
+	rank := func(precision int) int {
+		for index, interval := range precisionOrder {
+			if interval == precision {
+				return index
+			}
+		}
+
+		return -1
+	}
+
+	if rank(b) > rank(a) {
+		return b
+	}
+
+	return a
+}
+
+// truncate returns the start of the interval that t falls in
+func truncate(t time.Time, interval int) time.Time {
+	year, month, day := t.Date()
+
+	switch interval {
+	case INTERVAL_HOUR:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case INTERVAL_MINUTE:
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, t.Location())
+	case INTERVAL_SECOND:
+		return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	}
+
+	return periodStart(t, interval)
+}
+
+// periodStart returns midnight of the first day of the period that t falls in
+func periodStart(t time.Time, period int) time.Time {
+	year, month, day := t.Date()
+
+	switch period {
+	case INTERVAL_WEEK:
+		day -= int(t.Weekday())
+	case INTERVAL_ISO_WEEK:
+		day -= (int(t.Weekday()) + 6) % 7
+	case INTERVAL_MONTH:
+		day = 1
+	case INTERVAL_QUARTER:
+		month = (month-1)/3*3 + 1
+		day = 1
+	case INTERVAL_HALF:
+		month = (month-1)/6*6 + 1
+		day = 1
+	case INTERVAL_YEAR, INTERVAL_FISCAL_YEAR:
+		month = time.January
+		day = 1
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// fiscalPeriodStart returns the start of the period that t falls in the same
+// way as periodStart, except that quarters, halves and fiscal years are counted
+// from a fiscal year which starts shift months after january
+func fiscalPeriodStart(t time.Time, period, shift int) time.Time {
+	switch period {
+	case INTERVAL_QUARTER, INTERVAL_HALF, INTERVAL_FISCAL_YEAR:
+	default:
+		return periodStart(t, period)
+	}
+
+	// the first of the month can be moved by months without spilling over
+	// into the next one
+	month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return periodStart(month.AddDate(0, -shift, 0), period).AddDate(0, shift, 0)
+}
+
+// fiscalYear returns the start of the fiscal year named after the calendar year
+// that it ends in
+func fiscalYear(year, shift int, location *time.Location) time.Time {
+	if shift == 0 {
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+	}
+
+	return time.Date(year-1, time.Month(shift+1), 1, 0, 0, 0, 0, location)
+}
+
+// isoWeek returns the monday that starts an ISO week. The first week of a year
+// is the one with its first thursday, which always holds january 4th.
+func isoWeek(year, week int, location *time.Location) (time.Time, error) {
+	fourth := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
+	monday := fourth.AddDate(0, 0, 7*(week-1)-(int(fourth.Weekday())+6)%7)
+
+	if actual, _ := monday.ISOWeek(); week < 1 || actual != year {
+		return monday, ErrDayOutOfRange
+	}
+
+	return monday, nil
+}
+
+// periodEnd returns the start of the period which follows the one starting at
+// start
+func periodEnd(start time.Time, period int) time.Time {
+	switch period {
+	case INTERVAL_WEEK, INTERVAL_ISO_WEEK:
+		return start.AddDate(0, 0, 7)
+	case INTERVAL_MONTH:
+		return start.AddDate(0, 1, 0)
+	case INTERVAL_QUARTER:
+		return start.AddDate(0, 3, 0)
+	case INTERVAL_HALF:
+		return start.AddDate(0, 6, 0)
+	case INTERVAL_YEAR, INTERVAL_FISCAL_YEAR:
+		return start.AddDate(1, 0, 0)
+	case INTERVAL_HOUR:
+		return start.Add(time.Hour)
+	case INTERVAL_MINUTE:
+		return start.Add(time.Minute)
+	case INTERVAL_SECOND:
+		return start.Add(time.Second)
+	}
+
+	return start.AddDate(0, 0, 1)
+}
+
+type TimeContext struct {
+	size      int // number of successful elements that belong to this context
+	seconds   int // seconds past midnight, eg: 15:30 is 55800
+	precision int // the finest part of the clock that was written, eg: INTERVAL_MINUTE for 2:30pm
+}
+
+// Compile sets the clock of origin to the time of day held by the context,
+// keeping the calendar day and location of origin
+func (tc TimeContext) Compile(origin time.Time) (time.Time, error) {
+	if tc.seconds < 0 || tc.seconds >= 24*3600 {
+		return origin, ErrTimeOutOfRange
+	}
+
+	year, month, day := origin.Date()
+	hour := tc.seconds / 3600
+	minute := tc.seconds % 3600 / 60
+	second := tc.seconds % 60
+
+	return time.Date(year, month, day, hour, minute, second, 0, origin.Location()), nil
+}
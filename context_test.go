@@ -6,18 +6,20 @@ import (
 )
 
 func TestDateContextSynonym(t *testing.T) {
+	ref := time.Date(2015, time.June, 1, 9, 30, 0, 0, time.UTC)
+
 	testCases := []struct {
 		context  DateContext
 		date     time.Time
 		truncate time.Duration
 	}{
-		{DateContext{synonym: SYNONYM_TODAY}, time.Now(), time.Second},
-		{DateContext{synonym: SYNONYM_YESTERDAY}, time.Now().AddDate(0, 0, -1), time.Second},
-		{DateContext{synonym: SYNONYM_TOMORROW}, time.Now().AddDate(0, 0, 1), time.Second},
+		{DateContext{synonym: SYNONYM_TODAY}, ref, time.Second},
+		{DateContext{synonym: SYNONYM_YESTERDAY}, ref.AddDate(0, 0, -1), time.Second},
+		{DateContext{synonym: SYNONYM_TOMORROW}, ref.AddDate(0, 0, 1), time.Second},
 	}
 
 	for _, tc := range testCases {
-		actual, err := tc.context.compileSynonym()
+		actual, err := tc.context.compileSynonym(ref)
 		if err != nil {
 			t.Errorf("Unexpected error returned.")
 		}
@@ -32,12 +34,118 @@ func TestDateContextSynonym(t *testing.T) {
 }
 
 func TestRelativeDateContext(t *testing.T) {
-	// this is used to test something like 3rd wednesday of the month
-	// first month of the year
-	// first day of june
+	origin := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		context DateContext
+		date    [3]int
+	}{
+		{DateContext{
+			ordinal: 3,
+			unit:    INTERVAL_WEEKDAY,
+			weekday: WEEKDAY_WEDNESDAY,
+			period:  INTERVAL_MONTH,
+		}, [3]int{2015, 6, 17}},
+		{DateContext{
+			ordinal: -1,
+			unit:    INTERVAL_WEEKDAY,
+			weekday: WEEKDAY_FRIDAY,
+			period:  INTERVAL_MONTH,
+		}, [3]int{2015, 6, 26}},
+		{DateContext{
+			ordinal: 1,
+			unit:    INTERVAL_DAY,
+			period:  INTERVAL_MONTH,
+		}, [3]int{2015, 6, 1}},
+		{DateContext{
+			ordinal: -2,
+			unit:    INTERVAL_DAY,
+			period:  INTERVAL_YEAR,
+		}, [3]int{2015, 12, 30}},
+		{DateContext{
+			ordinal: 2,
+			unit:    INTERVAL_WEEK,
+			period:  INTERVAL_MONTH,
+		}, [3]int{2015, 6, 8}},
+	}
+
+	for _, tc := range testCases {
+		actual, err := tc.context.compileRelative(origin)
+		expected := time.Date(tc.date[0], time.Month(tc.date[1]), tc.date[2], 0, 0, 0, 0, time.UTC)
+
+		if err != nil {
+			t.Errorf("Unexpected error returned: %s", err)
+		}
+
+		if actual != expected {
+			t.Errorf("RelativeDateContext failed. expected: %s actual: %s", expected, actual)
+		}
+	}
+
+	// june only has four fridays
+	context := DateContext{ordinal: 5, unit: INTERVAL_WEEKDAY, weekday: WEEKDAY_FRIDAY, period: INTERVAL_MONTH}
+	if _, err := context.compileRelative(origin); err != ErrDayOutOfRange {
+		t.Errorf("Expected ErrDayOutOfRange, got: %v", err)
+	}
+}
+
+func TestBoundaryDateContext(t *testing.T) {
+	origin := time.Date(2015, time.June, 10, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		context  DateContext
+		expected time.Time
+	}{
+		{DateContext{boundary: BOUNDARY_START, period: INTERVAL_MONTH}, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{DateContext{boundary: BOUNDARY_END, period: INTERVAL_MONTH}, time.Date(2015, time.June, 30, 23, 59, 59, 999999999, time.UTC)},
+		{DateContext{boundary: BOUNDARY_MIDDLE, period: INTERVAL_DAY}, time.Date(2015, time.June, 10, 12, 0, 0, 0, time.UTC)},
+		{DateContext{boundary: BOUNDARY_START, period: INTERVAL_WEEK}, time.Date(2015, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{DateContext{boundary: BOUNDARY_END, period: INTERVAL_QUARTER}, time.Date(2015, time.June, 30, 23, 59, 59, 999999999, time.UTC)},
+		{DateContext{boundary: BOUNDARY_START, period: INTERVAL_YEAR}, time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		actual, err := tc.context.compileBoundary(origin)
+		if err != nil {
+			t.Errorf("Unexpected error returned: %s", err)
+		}
+
+		if !actual.Equal(tc.expected) {
+			t.Errorf("BoundaryDateContext failed. expected: %s actual: %s", tc.expected, actual)
+		}
+	}
+}
+
+func TestSpanDateContext(t *testing.T) {
+	origin := time.Date(2015, time.June, 10, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		context  DateContext
+		expected time.Time
+	}{
+		{DateContext{span: INTERVAL_QUARTER, index: 3}, time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{DateContext{span: INTERVAL_QUARTER}, time.Date(2015, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{DateContext{span: INTERVAL_HALF, index: 2, year: 2016}, time.Date(2016, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{DateContext{span: INTERVAL_FISCAL_YEAR, fiscal: 9}, time.Date(2014, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{DateContext{span: INTERVAL_FISCAL_YEAR, fiscal: 9, year: 2017}, time.Date(2016, time.October, 1, 0, 0, 0, 0, time.UTC)},
+		{DateContext{span: INTERVAL_QUARTER, index: 1, fiscal: 9}, time.Date(2014, time.October, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		actual, err := tc.context.compileSpan(origin)
+		if err != nil {
+			t.Errorf("Unexpected error returned: %s", err)
+		}
+
+		if !actual.Equal(tc.expected) {
+			t.Errorf("SpanDateContext failed. expected: %s actual: %s", tc.expected, actual)
+		}
+	}
 }
 
 func TestDateContext(t *testing.T) {
+	ref := time.Date(2014, time.March, 3, 0, 0, 0, 0, time.UTC)
+
 	testCases := []struct {
 		context DateContext
 		date    [3]int
@@ -54,11 +162,11 @@ func TestDateContext(t *testing.T) {
 		{DateContext{
 			month:    MONTH_DECEMBER,
 			monthday: 20,
-		}, [3]int{time.Now().Year(), 12, 20}},
+		}, [3]int{2014, 12, 20}},
 	}
 
 	for _, tc := range testCases {
-		actual, err := tc.context.compile()
+		actual, err := tc.context.compile(ref)
 		expected := time.Date(tc.date[0], time.Month(tc.date[1]), tc.date[2], 0, 0, 0, 0, time.UTC)
 
 		if err != nil {
@@ -70,3 +178,64 @@ func TestDateContext(t *testing.T) {
 		}
 	}
 }
+
+func TestTimeContext(t *testing.T) {
+	origin := time.Date(2015, time.June, 1, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		context TimeContext
+		date    time.Time
+	}{
+		{TimeContext{seconds: 0}, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{TimeContext{seconds: 15*3600 + 45*60 + 10}, time.Date(2015, time.June, 1, 15, 45, 10, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		actual, err := tc.context.Compile(origin)
+		if err != nil {
+			t.Errorf("Unexpected error returned")
+		}
+
+		if actual != tc.date {
+			t.Errorf("TimeContext failed. expected: %s actual: %s", tc.date, actual)
+		}
+	}
+
+	if _, err := (TimeContext{seconds: 24 * 3600}).Compile(origin); err == nil {
+		t.Errorf("TimeContext should have rejected an out of range time")
+	}
+}
+
+func TestOffsetContext(t *testing.T) {
+	origin := time.Date(2015, time.June, 1, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		context OffsetContext
+		date    time.Time
+	}{
+		{OffsetContext{interval: INTERVAL_HOUR, direction: DIRECTION_RIGHT, count: 3}, origin.Add(3 * time.Hour)},
+		{OffsetContext{interval: INTERVAL_MINUTE, direction: DIRECTION_LEFT, count: 45}, origin.Add(-45 * time.Minute)},
+		{OffsetContext{interval: INTERVAL_SECOND, direction: DIRECTION_RIGHT, count: 30}, origin.Add(30 * time.Second)},
+		{OffsetContext{interval: INTERVAL_CENTURY, direction: DIRECTION_LEFT, count: 1}, origin.AddDate(-100, 0, 0)},
+		{OffsetContext{interval: INTERVAL_FORTNIGHT, direction: DIRECTION_RIGHT, count: 1}, origin.AddDate(0, 0, 14)},
+		{OffsetContext{interval: INTERVAL_HOUR, direction: DIRECTION_CURRENT, count: 1}, origin},
+		{OffsetContext{interval: INTERVAL_DAY, direction: DIRECTION_LEFT, count: 3, terms: []offsetTerm{
+			{count: 1, interval: INTERVAL_YEAR},
+			{count: 2, interval: INTERVAL_MONTH},
+		}}, origin.AddDate(-1, -2, -3)},
+		{OffsetContext{interval: INTERVAL_MINUTE, direction: DIRECTION_RIGHT, count: 15, terms: []offsetTerm{
+			{count: 2, interval: INTERVAL_DAY},
+		}}, origin.AddDate(0, 0, 2).Add(15 * time.Minute)},
+	}
+
+	for _, tc := range testCases {
+		actual, err := tc.context.offset(origin)
+		if err != nil {
+			t.Errorf("Unexpected error returned")
+		}
+
+		if actual != tc.date {
+			t.Errorf("OffsetContext failed. expected: %s actual: %s", tc.date, actual)
+		}
+	}
+}
@@ -1,24 +1,187 @@
 package datelp
 
 import (
+	"io"
 	"strings"
 	"time"
 )
 
+const (
+	KIND_DATE       = iota << 1 // a calendar date such as june 1st or tomorrow
+	KIND_OFFSET                 // an offset from the reference such as next tuesday
+	KIND_COMBINED               // an offset from a date such as 2 days after june 1st
+	KIND_TIME                   // a time of day on the reference date such as 3pm
+	KIND_RANGE                  // a range between two dates such as june 1st to june 5th
+	KIND_RECURRENCE             // a repeating date such as every other tuesday
+)
+
+// KindName returns the lower case name of one of the KIND constants, such as
+// "range" for KIND_RANGE
+func KindName(kind int) string {
+	switch kind {
+	case KIND_DATE:
+		return "date"
+	case KIND_OFFSET:
+		return "offset"
+	case KIND_COMBINED:
+		return "combined"
+	case KIND_TIME:
+		return "time"
+	case KIND_RANGE:
+		return "range"
+	case KIND_RECURRENCE:
+		return "recurrence"
+	}
+
+	return ""
+}
+
 type Result struct {
 	Size int
 	Date time.Time
+	Kind int
+
+	// set for KIND_RANGE, in which case Date is the start of the range
+	Range *Range
+	// set for KIND_RECURRENCE, in which case Date is the next occurrence
+	Recurrence *Recurrence
+
+	// the finest interval that the input expressed: INTERVAL_YEAR,
+	// INTERVAL_MONTH, INTERVAL_WEEK, INTERVAL_DAY, INTERVAL_HOUR,
+	// INTERVAL_MINUTE or INTERVAL_SECOND, or the quarter, half or fiscal
+	// year of a span. "june 2015" is precise to the month while "june 1st
+	// 2015" is precise to the day, even though both have the same Date.
+	Precision int
+
+	// the components of Date that the input expressed
+	Explicit Components
+
+	// the interval covering Date at its precision, such as all of june for
+	// "june 2015". A range is covered by itself.
+	Period *Range
+}
+
+// Components records which parts of a date were expressed by the input,
+// either written out or counted from the reference as in "tomorrow". The
+// others were defaulted, to the reference as the year of "june 1st" or to the
+// start of the period as the day of "june 2015".
+type Components struct {
+	Year   bool
+	Month  bool
+	Day    bool
+	Hour   bool
+	Minute bool
+}
+
+// Match is a single date mention found in a larger body of text
+type Match struct {
+	Result
+	Text  string // the text of the mention, as it appeared in the input
+	Start int    // byte offset of the first byte of the mention
+	End   int    // byte offset immediately after the mention
 }
 
 func Parse(input string) (time.Time, error) {
-	classifier := NewClassifier()
+	return ParseAt(input, time.Now())
+}
+
+// ParseAt parses input the same way as Parse, except that relative
+// expressions such as "tomorrow" or "next tuesday" are resolved against ref
+// instead of the current time.
+func ParseAt(input string, ref time.Time) (time.Time, error) {
+	classifier := NewClassifier(WithReference(ref))
 	stringReader := strings.NewReader(input)
 	iterator := NewWordIterator(stringReader)
 
 	results, err := classifier.Parse(iterator)
 	if err != nil {
-		return time.Now(), err
+		return ref, err
 	}
 
 	return results.Date, nil
 }
+
+// ParseAll returns every plausible interpretation of input, ordered from the
+// most to the least likely. The first candidate is the one Parse returns.
+func ParseAll(input string) ([]Candidate, error) {
+	return ParseAllAt(input, time.Now())
+}
+
+// ParseAllAt returns the interpretations of input the same way as ParseAll,
+// except that relative expressions are resolved against ref.
+func ParseAllAt(input string, ref time.Time) ([]Candidate, error) {
+	classifier := NewClassifier(WithReference(ref))
+	iterator := NewWordIterator(strings.NewReader(input))
+
+	return classifier.ParseAll(iterator)
+}
+
+// ParseRange parses a range expression such as "from june 1st to june 5th"
+// or "between monday and friday"
+func ParseRange(input string) (*Range, error) {
+	return ParseRangeAt(input, time.Now())
+}
+
+// ParseRangeAt parses a range the same way as ParseRange, except that
+// relative ends of the range are resolved against ref.
+func ParseRangeAt(input string, ref time.Time) (*Range, error) {
+	classifier := NewClassifier(WithReference(ref))
+	iterator := NewWordIterator(strings.NewReader(input))
+
+	result, err := classifier.Parse(iterator)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Range == nil {
+		return nil, ErrNoRange
+	}
+
+	return result.Range, nil
+}
+
+// ParseDuration parses a span of time such as "2 weeks and 3 days" or "a
+// fortnight". The duration can be applied to any time with its AddTo method.
+func ParseDuration(input string) (Duration, error) {
+	classifier := NewClassifier()
+	iterator := NewWordIterator(strings.NewReader(input))
+
+	duration, err := classifier.ParseDuration(iterator)
+	if err != nil {
+		return Duration{}, err
+	}
+
+	return *duration, nil
+}
+
+// Extract returns every date mention found in text, in the order that they
+// appear
+func Extract(text string) []Match {
+	return ExtractAt(text, time.Now())
+}
+
+// ExtractAt extracts date mentions the same way as Extract, except that
+// relative mentions are resolved against ref instead of the current time.
+func ExtractAt(text string, ref time.Time) []Match {
+	classifier := NewClassifier(WithReference(ref))
+	iterator := NewWordIterator(strings.NewReader(text))
+
+	// an empty iterator has no current word to classify
+	if _, err := iterator.NextNth(0); err != nil {
+		return []Match{}
+	}
+
+	matches := classifier.Extract(iterator)
+	for index := range matches {
+		matches[index].Text = text[matches[index].Start:matches[index].End]
+	}
+
+	return matches
+}
+
+// ExtractFrom extracts date mentions from input as it is read and passes
+// each one to fn, without holding all of input in memory. It returns the
+// first error of reading input.
+func ExtractFrom(input io.Reader, fn func(Match)) error {
+	return NewClassifier(WithReference(time.Now())).ExtractFrom(input, fn)
+}
@@ -0,0 +1,144 @@
+package datelp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAllAt(t *testing.T) {
+	ref := sunday
+
+	candidates, err := ParseAllAt("next june", ref)
+	if err != nil || len(candidates) != 2 {
+		t.Fatalf("ParseAllAt returned wrong candidates: %v %v", candidates, err)
+	}
+
+	if _, err := ParseAllAt("", ref); err == nil {
+		t.Errorf("ParseAllAt should have rejected empty input")
+	}
+}
+
+func TestParseRangeAt(t *testing.T) {
+	ref := sunday
+
+	r, err := ParseRangeAt("june 1-5", ref)
+	if err != nil {
+		t.Fatalf("Unexpected error returned: %s", err)
+	}
+
+	if r.Start.Day() != 1 || r.End.Day() != 5 || !r.Inclusive {
+		t.Errorf("ParseRangeAt failed. actual: %s - %s", r.Start, r.End)
+	}
+
+	// weeks resolve to a range of their own
+	r, err = ParseRangeAt("the week of june 3rd", ref)
+	if err != nil || r.Start.Day() != 1 || r.End.Day() != 8 || r.Inclusive {
+		t.Errorf("ParseRangeAt failed for a week. actual: %v %v", r, err)
+	}
+
+	if _, err := ParseRangeAt("tomorrow", ref); err == nil {
+		t.Errorf("ParseRangeAt should have rejected a single date")
+	}
+}
+
+type expectedMatch struct {
+	text  string
+	start int
+	end   int
+	date  time.Time
+	kind  int
+}
+
+func TestParseDuration(t *testing.T) {
+	duration, err := ParseDuration("2 weeks and 3 days")
+	if err != nil {
+		t.Fatalf("Unexpected error returned: %s", err)
+	}
+
+	if expected := (Duration{Days: 17}); duration != expected {
+		t.Errorf("ParseDuration failed. expected: %+v actual: %+v", expected, duration)
+	}
+
+	if _, err := ParseDuration("tomorrow"); err == nil {
+		t.Errorf("ParseDuration should have rejected input without a duration")
+	}
+}
+
+func TestExtractAt(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		input    string
+		expected []expectedMatch
+	}{
+		{"met on june 1st and again next tuesday", []expectedMatch{
+			{"june 1st", 7, 15, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC), KIND_DATE},
+			{"next tuesday", 26, 38, ref.AddDate(0, 0, 9), KIND_OFFSET},
+		}},
+		{"call back tomorrow", []expectedMatch{
+			{"tomorrow", 10, 18, ref.AddDate(0, 0, 1), KIND_DATE},
+		}},
+		{"the day after tomorrow works", []expectedMatch{
+			{"day after tomorrow", 4, 22, ref.AddDate(0, 0, 2), KIND_COMBINED},
+		}},
+		{"lunch tomorrow at 1 pm ok", []expectedMatch{
+			{"tomorrow at 1 pm", 6, 22, time.Date(2015, time.June, 1, 13, 0, 0, 0, time.UTC), KIND_DATE},
+		}},
+		{"out from june 1 to june 5, back after", []expectedMatch{
+			{"from june 1 to june 5", 4, 25, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC), KIND_RANGE},
+		}},
+		{"standup every tuesday at 9am sharp", []expectedMatch{
+			{"every tuesday at 9am", 8, 28, time.Date(2015, time.June, 2, 9, 0, 0, 0, time.UTC), KIND_RECURRENCE},
+		}},
+		{"off june 30 - july 2 for the trip", []expectedMatch{
+			{"june 30 - july 2", 4, 20, time.Date(2015, time.June, 30, 0, 0, 0, 0, time.UTC), KIND_RANGE},
+		}},
+		{"free between monday and friday", []expectedMatch{
+			{"between monday and friday", 5, 30, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC), KIND_RANGE},
+		}},
+		{"backups run nightly at 2am", []expectedMatch{
+			{"nightly at 2am", 12, 26, time.Date(2015, time.June, 1, 2, 0, 0, 0, time.UTC), KIND_RECURRENCE},
+		}},
+		{"call 555 1234 friday at 3pm", []expectedMatch{
+			{"friday at 3pm", 14, 27, time.Date(2015, time.June, 5, 15, 0, 0, 0, time.UTC), KIND_OFFSET},
+		}},
+		{"scores were 10 12 14 tomorrow", []expectedMatch{
+			{"tomorrow", 21, 29, ref.AddDate(0, 0, 1), KIND_DATE},
+		}},
+		{"id " + strings.Repeat("7 ", 80) + "tomorrow", []expectedMatch{
+			{"tomorrow", 163, 171, ref.AddDate(0, 0, 1), KIND_DATE},
+		}},
+		{"tomorrow, (June 5th", []expectedMatch{
+			{"tomorrow", 0, 8, ref.AddDate(0, 0, 1), KIND_DATE},
+			{"June 5th", 11, 19, time.Date(2015, time.June, 5, 0, 0, 0, 0, time.UTC), KIND_DATE},
+		}},
+		{"june" + strings.Repeat(" the", 100) + " x tomorrow", []expectedMatch{
+			{"june", 0, 4, time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC), KIND_DATE},
+			{"tomorrow", 407, 415, ref.AddDate(0, 0, 1), KIND_DATE},
+		}},
+		{"bought 3 apples", []expectedMatch{}},
+		{"", []expectedMatch{}},
+	}
+
+	for _, tc := range testCases {
+		matches := ExtractAt(tc.input, ref)
+		if len(matches) != len(tc.expected) {
+			t.Errorf("Extract \"%s\" found %d matches. expected: %d", tc.input, len(matches), len(tc.expected))
+			continue
+		}
+
+		for index, expected := range tc.expected {
+			actual := matches[index]
+			if actual.Text != expected.text || actual.Start != expected.start || actual.End != expected.end {
+				t.Errorf("Extract returned wrong span. expected: %q [%d:%d] actual: %q [%d:%d]",
+					expected.text, expected.start, expected.end, actual.Text, actual.Start, actual.End)
+			}
+
+			if !actual.Date.Equal(expected.date) || actual.Kind != expected.kind {
+				t.Errorf("Extract \"%s\" resolved wrong. expected: %s (%d) actual: %s (%d)",
+					expected.text, expected.date, expected.kind, actual.Date, actual.Kind)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package datelp
+
+import (
+	"time"
+)
+
+// the maximum number of words that a duration is searched across
+const durationWindow = 16
+
+// Duration is a span of calendar time such as "1 month and 2 hours". Unlike a
+// time.Duration its years, months and days are applied on the calendar, so
+// that a month after january 15th is always february 15th. Clock is added
+// afterwards.
+type Duration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+// AddTo returns the time that the duration ends at when it starts at t
+func (d Duration) AddTo(t time.Time) time.Time {
+	return t.AddDate(d.Years, d.Months, d.Days).Add(d.Clock)
+}
+
+// Negate returns the duration pointing the other way, so that AddTo returns
+// the time that it would have started at
+func (d Duration) Negate() Duration {
+	return Duration{
+		Years:  -d.Years,
+		Months: -d.Months,
+		Days:   -d.Days,
+		Clock:  -d.Clock,
+	}
+}
+
+func (d Duration) IsZero() bool {
+	return d == Duration{}
+}
+
+// addInterval adds count of one of the INTERVAL constants to the duration
+func (d *Duration) addInterval(interval, count int) error {
+	switch interval {
+	case INTERVAL_SECOND:
+		d.Clock += time.Duration(count) * time.Second
+	case INTERVAL_MINUTE:
+		d.Clock += time.Duration(count) * time.Minute
+	case INTERVAL_HOUR:
+		d.Clock += time.Duration(count) * time.Hour
+	case INTERVAL_DAY:
+		d.Days += count
+	case INTERVAL_WEEK:
+		d.Days += 7 * count
+	case INTERVAL_FORTNIGHT:
+		d.Days += 14 * count
+	case INTERVAL_MONTH:
+		d.Months += count
+	case INTERVAL_QUARTER:
+		d.Months += 3 * count
+	case INTERVAL_YEAR:
+		d.Years += count
+	case INTERVAL_CENTURY:
+		d.Years += 100 * count
+	default:
+		return ErrInvalidOffset
+	}
+
+	return nil
+}
+
+// ParseDuration returns the first duration found from the current position
+// of the iterator onwards, such as "2 weeks and 3 days" or "a fortnight"
+func (c *Classifier) ParseDuration(i Iterator) (*Duration, error) {
+	if _, err := i.NextNth(0); err != nil {
+		return nil, ErrNoDuration
+	}
+
+	origin := newParseError(ErrNoDuration, i, "duration")
+	for {
+		if duration, err := c.parseDuration(i); err == nil {
+			return duration, nil
+		}
+
+		if err := i.Move(); err != nil {
+			return nil, origin
+		}
+	}
+}
+
+func (c *Classifier) parseDuration(i Iterator) (*Duration, error) {
+	/*
+	   Parse the terms of a duration starting at the current position of
+	   the iterator. Each term is a count followed by an interval, and the
+	   count may be left out:
+
+	           `2 weeks and 3 days`
+	           `1 year 6 months`
+	           `a fortnight`
+	*/
+	words := make([]string, 0)
+	for n := 0; n < durationWindow; n++ {
+		word, err := i.NextNth(n)
+		if err != nil {
+			break
+		}
+		words = append(words, word)
+	}
+
+	duration := &Duration{}
+	terms := 0
+	count := 1
+	counted := false
+
+	iterator := newTokenIterator(words)
+	for {
+		step := 1
+
+		// the interval is checked before the count since some words
+		// such as "second" are both
+		if interval, err := c.lang().ClassifyAsInterval(iterator); err == nil {
+			if err := duration.addInterval(interval, count); err != nil {
+				return nil, newParseError(err, iterator, "duration")
+			}
+			terms += 1
+			count, counted = 1, false
+		} else if c.lang().ClassifyWordAsCommon(iterator.Current()) {
+			// common words such as "and" join the terms together
+		} else if value, size, err := c.lang().ClassifyAsIntegerStem(iterator); err == nil && !counted {
+			count, counted, step = value, true, size
+		} else {
+			break
+		}
+
+		if err := iterator.MoveN(step); err != nil {
+			break
+		}
+	}
+
+	if terms == 0 {
+		return nil, newParseError(ErrNoDuration, i, "duration")
+	}
+
+	return duration, nil
+}
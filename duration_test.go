@@ -0,0 +1,66 @@
+package datelp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClassifierParseDuration(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Duration
+	}{
+		{"2 weeks and 3 days", Duration{Days: 17}},
+		{"3 days and 2 weeks", Duration{Days: 17}},
+		{"a fortnight", Duration{Days: 14}},
+		{"1 year 6 months", Duration{Years: 1, Months: 6}},
+		{"1 month", Duration{Months: 1}},
+		{"two hours and 30 minutes", Duration{Clock: 2*time.Hour + 30*time.Minute}},
+		{"1 second", Duration{Clock: time.Second}},
+		{"a day and an hour", Duration{Days: 1, Clock: time.Hour}},
+		{"remind me in twenty three days please", Duration{Days: 23}},
+		{"a century", Duration{Years: 100}},
+	}
+
+	for _, tc := range testCases {
+		duration, err := NewClassifier().ParseDuration(newWordIterator(tc.input))
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+			continue
+		}
+
+		if *duration != tc.expected {
+			t.Errorf("Did not convert \"%s\". Expected: %+v Actual: %+v", tc.input, tc.expected, *duration)
+		}
+	}
+}
+
+func TestClassifierParseDurationErrors(t *testing.T) {
+	for _, input := range []string{"", "tomorrow", "3 apples"} {
+		_, err := NewClassifier().ParseDuration(newWordIterator(input))
+		if !errors.Is(err, ErrNoDuration) {
+			t.Errorf("\"%s\" returned wrong error. expected: %s actual: %v", input, ErrNoDuration, err)
+		}
+	}
+}
+
+func TestDurationAddTo(t *testing.T) {
+	anchor := time.Date(2015, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		duration Duration
+		expected time.Time
+	}{
+		{Duration{Months: 1}, time.Date(2015, time.February, 15, 12, 0, 0, 0, time.UTC)},
+		{Duration{Years: 1, Days: 2, Clock: 90 * time.Minute}, time.Date(2016, time.January, 17, 13, 30, 0, 0, time.UTC)},
+		{Duration{Days: 14}.Negate(), time.Date(2015, time.January, 1, 12, 0, 0, 0, time.UTC)},
+		{Duration{}, anchor},
+	}
+
+	for _, tc := range testCases {
+		if actual := tc.duration.AddTo(anchor); !actual.Equal(tc.expected) {
+			t.Errorf("%+v added wrong. expected: %s actual: %s", tc.duration, tc.expected, actual)
+		}
+	}
+}
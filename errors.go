@@ -0,0 +1,68 @@
+package datelp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors which every error returned by the package wraps, so that
+// callers can tell them apart with errors.Is. In particular ErrNoDate means
+// that the input did not contain a date at all, while the others mean that a
+// date was present but was not valid.
+var (
+	ErrNoDate          = errors.New("no date found")
+	ErrNoRange         = errors.New("no range found")
+	ErrNoDuration      = errors.New("no duration found")
+	ErrUnrecognized    = errors.New("unrecognized word")
+	ErrTrailingInput   = errors.New("unparsed trailing input")
+	ErrDayOutOfRange   = errors.New("day out of range")
+	ErrTimeOutOfRange  = errors.New("time of day out of range")
+	ErrInvalidMonth    = errors.New("invalid month")
+	ErrInvalidWeekday  = errors.New("invalid weekday")
+	ErrInvalidSynonym  = errors.New("invalid day synonym")
+	ErrInvalidOffset   = errors.New("invalid offset")
+	ErrInvalidBoundary = errors.New("invalid boundary")
+	ErrInvalidRange    = errors.New("range ends before it starts")
+	ErrOutOfRange      = errors.New("iterator out of range")
+)
+
+// ParseError is returned when a particular word of the input could not be
+// parsed. It wraps one of the sentinel errors above and can be inspected with
+// errors.As.
+type ParseError struct {
+	Err      error  // the sentinel error describing what went wrong
+	Token    string // the offending word
+	Index    int    // position of the offending word in the iterator, -1 if unknown
+	Expected string // what the word was classified as, eg: weekday
+}
+
+func (e *ParseError) Error() string {
+	message := e.Err.Error()
+	if e.Token != "" {
+		message = fmt.Sprintf("%s %q", message, e.Token)
+	}
+
+	if e.Index >= 0 {
+		message = fmt.Sprintf("%s at word %d", message, e.Index)
+	}
+
+	if e.Expected != "" {
+		message = fmt.Sprintf("%s: expected %s", message, e.Expected)
+	}
+
+	return message
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError builds a ParseError for the current word of the iterator
+func newParseError(err error, i Iterator, expected string) *ParseError {
+	return &ParseError{
+		Err:      err,
+		Token:    i.Current(),
+		Index:    i.Index(),
+		Expected: expected,
+	}
+}
@@ -0,0 +1,97 @@
+package datelp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		input  string
+		strict bool
+		err    error
+		token  string
+		index  int
+	}{
+		{"hello world", false, ErrNoDate, "hello", 0},
+		{"june 45th", false, ErrDayOutOfRange, "45th", 1},
+		{"june 1st blah", true, ErrTrailingInput, "blah", 2},
+		{"blah june 1st", true, ErrUnrecognized, "blah", 0},
+		{"from june 1 to june 5 ok", true, ErrTrailingInput, "ok", 6},
+		{"3pm sharp", true, ErrTrailingInput, "sharp", 1},
+		{"2014-W53", false, ErrDayOutOfRange, "2014-w53", 0},
+		{"february 30 2015", false, ErrDayOutOfRange, "30", 1},
+		{"feb 30", false, ErrDayOutOfRange, "30", 1},
+		{"due 02/30/2015", false, ErrDayOutOfRange, "02/30/2015", 1},
+		{"0am", false, ErrTimeOutOfRange, "0am", 0},
+		{"tomorrow at 0 am", false, ErrTimeOutOfRange, "0", 2},
+		{"13pm", false, ErrTimeOutOfRange, "13pm", 0},
+		{"Q5 2016", false, ErrDayOutOfRange, "q5", 0},
+		{"fy 2017 h3", false, ErrDayOutOfRange, "h3", 2},
+		{"q0", false, ErrDayOutOfRange, "q0", 0},
+		{"2016-W23-8", false, ErrDayOutOfRange, "2016-w23-8", 0},
+		{"week 0", false, ErrDayOutOfRange, "0", 1},
+		{"week 54 2016", false, ErrDayOutOfRange, "54", 1},
+		{"due w54", false, ErrDayOutOfRange, "w54", 1},
+	}
+
+	for _, tc := range testCases {
+		options := []Option{WithReference(ref)}
+		if tc.strict {
+			options = append(options, WithStrict())
+		}
+
+		_, err := NewClassifier(options...).Parse(newWordIterator(tc.input))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\"%s\" returned the wrong error. expected: %s actual: %v", tc.input, tc.err, err)
+			continue
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("\"%s\" did not return a ParseError", tc.input)
+			continue
+		}
+
+		if parseErr.Token != tc.token || parseErr.Index != tc.index {
+			t.Errorf("\"%s\" returned the wrong token. expected: %q (%d) actual: %q (%d)",
+				tc.input, tc.token, tc.index, parseErr.Token, parseErr.Index)
+		}
+	}
+}
+
+func TestParseErrorsWithoutToken(t *testing.T) {
+	ref := sunday
+
+	if _, err := ConstantToWeekday(-1); !errors.Is(err, ErrInvalidWeekday) {
+		t.Errorf("ConstantToWeekday returned the wrong error. actual: %v", err)
+	}
+
+	if _, err := ConstantToMonth(-1); !errors.Is(err, ErrInvalidMonth) {
+		t.Errorf("ConstantToMonth returned the wrong error. actual: %v", err)
+	}
+
+	if _, err := ParseRangeAt("tomorrow", ref); !errors.Is(err, ErrNoRange) {
+		t.Errorf("ParseRangeAt returned the wrong error. actual: %v", err)
+	}
+}
+
+func TestStrictParse(t *testing.T) {
+	ref := sunday
+
+	for _, input := range []string{"june 1st", "the day after tomorrow", "every tuesday", "next tuesday at 3pm"} {
+		_, err := NewClassifier(WithReference(ref), WithStrict()).Parse(newWordIterator(input))
+		if err != nil {
+			t.Errorf("\"%s\" should have been parsed strictly. actual: %s", input, err)
+		}
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	err := &ParseError{Err: ErrUnrecognized, Token: "blah", Index: 2, Expected: "weekday"}
+	if expected := `unrecognized word "blah" at word 2: expected weekday`; err.Error() != expected {
+		t.Errorf("ParseError message was wrong. expected: %s actual: %s", expected, err.Error())
+	}
+}
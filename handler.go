@@ -0,0 +1,268 @@
+package datelp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// the largest request body that the handler reads, in bytes, which is kept
+// small enough to be parsed well within the write timeout of the server
+const maxRequestSize = 1 << 16
+
+// apiRequest is the body of a request to the handler. Every field but Text is
+// optional.
+type apiRequest struct {
+	Text      string `json:"text"`
+	Reference string `json:"reference"` // RFC 3339, the time of the request when unset
+	Zone      string `json:"zone"`      // IANA time zone, eg: Europe/Paris
+	Locale    string `json:"locale"`    // language of the text, eg: es or es-MX
+	Strict    bool   `json:"strict"`
+}
+
+type apiRange struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Inclusive bool      `json:"inclusive"`
+}
+
+type apiResult struct {
+	Text       string    `json:"text,omitempty"`
+	Start      *int      `json:"start,omitempty"`
+	End        *int      `json:"end,omitempty"`
+	Kind       string    `json:"kind"`
+	Date       time.Time `json:"date"`
+	Range      *apiRange `json:"range,omitempty"`
+	Recurrence string    `json:"recurrence,omitempty"` // RFC 5545 RRULE
+	Precision  string    `json:"precision,omitempty"`  // eg: month for june 2015
+	Explicit   []string  `json:"explicit"`             // the components that the text expressed, eg: ["year", "month"]
+	Period     *apiRange `json:"period,omitempty"`     // the interval covering date at its precision
+	Score      float64   `json:"score,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Token   string `json:"token,omitempty"`
+	Start   *int   `json:"start,omitempty"`
+	End     *int   `json:"end,omitempty"`
+}
+
+type apiResponse struct {
+	Result     *apiResult  `json:"result,omitempty"`
+	Candidates []apiResult `json:"candidates,omitempty"`
+	Matches    []apiResult `json:"matches,omitempty"`
+	Error      *apiError   `json:"error,omitempty"`
+}
+
+// Handler serves the classifier over HTTP with two endpoints, which both
+// accept a JSON body such as {"text": "next tuesday", "zone": "Europe/Paris"}:
+//
+//	POST /parse    the most likely date of the text along with every candidate
+//	POST /extract  every date mentioned in the text along with its byte offsets
+//
+// It can be mounted under a prefix of another server with http.StripPrefix.
+type Handler struct {
+	options []Option
+	mux     *http.ServeMux
+}
+
+// NewHandler returns a handler whose classifiers are configured with options.
+// The reference, zone, locale and strictness of a request are applied on top
+// of them.
+func NewHandler(options ...Option) *Handler {
+	h := &Handler{options: options, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/parse", h.parse)
+	h.mux.HandleFunc("/extract", h.extract)
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) parse(w http.ResponseWriter, r *http.Request) {
+	request, classifier, ok := h.classifier(w, r)
+	if !ok {
+		return
+	}
+
+	candidates, err := classifier.ParseAll(NewWordIterator(strings.NewReader(request.Text)))
+	if err != nil {
+		writeResponse(w, http.StatusUnprocessableEntity, apiResponse{Error: newAPIError(err, request.Text)})
+		return
+	}
+
+	response := apiResponse{Candidates: make([]apiResult, 0)}
+	for _, candidate := range candidates {
+		result := newAPIResult(candidate.Result)
+		result.Score = candidate.Score
+		result.Reason = candidate.Reason
+		response.Candidates = append(response.Candidates, result)
+	}
+	response.Result = &response.Candidates[0]
+
+	writeResponse(w, http.StatusOK, response)
+}
+
+func (h *Handler) extract(w http.ResponseWriter, r *http.Request) {
+	request, classifier, ok := h.classifier(w, r)
+	if !ok {
+		return
+	}
+
+	response := apiResponse{Matches: make([]apiResult, 0)}
+	iterator := NewWordIterator(strings.NewReader(request.Text))
+
+	// an empty iterator has no current word to classify
+	if _, err := iterator.NextNth(0); err == nil {
+		for _, match := range classifier.Extract(iterator) {
+			start, end := match.Start, match.End
+			result := newAPIResult(match.Result)
+			result.Text = request.Text[start:end]
+			result.Start, result.End = &start, &end
+			response.Matches = append(response.Matches, result)
+		}
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
+
+// classifier decodes the request and builds a classifier for it. When the
+// request is invalid an error is written and ok is false.
+func (h *Handler) classifier(w http.ResponseWriter, r *http.Request) (apiRequest, *Classifier, bool) {
+	request := apiRequest{}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeResponse(w, http.StatusMethodNotAllowed, apiResponse{Error: &apiError{Code: "method_not_allowed", Message: "only POST is supported"}})
+		return request, nil, false
+	}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request); err != nil {
+		writeResponse(w, http.StatusBadRequest, apiResponse{Error: &apiError{Code: "invalid_request", Message: err.Error()}})
+		return request, nil, false
+	}
+
+	options := append([]Option{}, h.options...)
+	if request.Reference != "" {
+		ref, err := time.Parse(time.RFC3339, request.Reference)
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, apiResponse{Error: &apiError{Code: "invalid_reference", Message: err.Error()}})
+			return request, nil, false
+		}
+		options = append(options, WithReference(ref))
+	}
+
+	if request.Zone != "" {
+		location, err := time.LoadLocation(request.Zone)
+		if err != nil {
+			writeResponse(w, http.StatusBadRequest, apiResponse{Error: &apiError{Code: "invalid_zone", Message: err.Error()}})
+			return request, nil, false
+		}
+		options = append(options, WithLocation(location))
+	}
+
+	if request.Locale != "" {
+		// only the language of a locale such as es-MX is used
+		code := strings.ToLower(request.Locale)
+		if index := strings.IndexAny(code, "-_"); index >= 0 {
+			code = code[:index]
+		}
+
+		language, exists := LookupLanguage(code)
+		if !exists {
+			writeResponse(w, http.StatusBadRequest, apiResponse{Error: &apiError{Code: "invalid_locale", Message: "unsupported locale " + request.Locale}})
+			return request, nil, false
+		}
+		options = append(options, WithLanguage(language))
+	}
+
+	if request.Strict {
+		options = append(options, WithStrict())
+	}
+
+	return request, NewClassifier(options...), true
+}
+
+func newAPIResult(result Result) apiResult {
+	api := apiResult{
+		Kind:      KindName(result.Kind),
+		Date:      result.Date,
+		Precision: IntervalName(result.Precision),
+		Explicit:  make([]string, 0),
+	}
+
+	if result.Range != nil {
+		api.Range = &apiRange{Start: result.Range.Start, End: result.Range.End, Inclusive: result.Range.Inclusive}
+	}
+
+	if result.Period != nil {
+		api.Period = &apiRange{Start: result.Period.Start, End: result.Period.End, Inclusive: result.Period.Inclusive}
+	}
+
+	components := []struct {
+		explicit bool
+		name     string
+	}{
+		{result.Explicit.Year, "year"},
+		{result.Explicit.Month, "month"},
+		{result.Explicit.Day, "day"},
+		{result.Explicit.Hour, "hour"},
+		{result.Explicit.Minute, "minute"},
+	}
+	for _, component := range components {
+		if component.explicit {
+			api.Explicit = append(api.Explicit, component.name)
+		}
+	}
+
+	if result.Recurrence != nil {
+		api.Recurrence = result.Recurrence.RRule()
+	}
+
+	return api
+}
+
+// newAPIError describes err along with the byte offsets of the word of text
+// that caused it, when known
+func newAPIError(err error, text string) *apiError {
+	codes := []struct {
+		err  error
+		code string
+	}{
+		{ErrNoDate, "no_date"},
+		{ErrUnrecognized, "unrecognized"},
+		{ErrTrailingInput, "trailing_input"},
+		{ErrDayOutOfRange, "day_out_of_range"},
+		{ErrTimeOutOfRange, "time_out_of_range"},
+	}
+
+	api := &apiError{Code: "invalid_date", Message: err.Error()}
+	for _, known := range codes {
+		if errors.Is(err, known.err) {
+			api.Code = known.code
+			break
+		}
+	}
+
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		api.Token = parseErr.Token
+		if tokens := Tokenize(text); parseErr.Index >= 0 && parseErr.Index < len(tokens) {
+			start, end := tokens[parseErr.Index].Start, tokens[parseErr.Index].End
+			api.Start, api.End = &start, &end
+		}
+	}
+
+	return api
+}
+
+func writeResponse(w http.ResponseWriter, status int, response apiResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,109 @@
+package datelp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func serve(t *testing.T, method, path, body string) (int, apiResponse) {
+	ref := sunday
+	handler := http.StripPrefix("/api", NewHandler(WithReference(ref)))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+	response := apiResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Invalid JSON response %q: %s", recorder.Body.String(), err)
+	}
+
+	return recorder.Code, response
+}
+
+func TestHandlerParse(t *testing.T) {
+	status, response := serve(t, "POST", "/api/parse", `{"text": "tomorrow at 3pm", "zone": "Asia/Tokyo"}`)
+	if status != http.StatusOK || response.Result == nil {
+		t.Fatalf("Unexpected response %d: %+v", status, response)
+	}
+
+	expected := time.Date(2015, time.June, 1, 15, 0, 0, 0, time.FixedZone("JST", 9*3600))
+	if !response.Result.Date.Equal(expected) || response.Result.Kind != "date" {
+		t.Errorf("Wrong result. expected: %s actual: %s (%s)", expected, response.Result.Date, response.Result.Kind)
+	}
+
+	// the request can override the reference and language
+	_, response = serve(t, "POST", "/api/parse", `{"text": "mañana", "locale": "es-MX", "reference": "2016-01-01T00:00:00Z"}`)
+	if response.Result == nil || !response.Result.Date.Equal(time.Date(2016, time.January, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Request options were not applied: %+v", response)
+	}
+
+	// ambiguous text returns every candidate
+	_, response = serve(t, "POST", "/api/parse", `{"text": "friday"}`)
+	if len(response.Candidates) < 2 || response.Candidates[0].Score <= response.Candidates[1].Score {
+		t.Errorf("Expected ranked candidates: %+v", response.Candidates)
+	}
+
+	// the precision and the components that were written are reported
+	_, response = serve(t, "POST", "/api/parse", `{"text": "june 2015"}`)
+	if result := response.Result; result == nil || result.Precision != "month" || strings.Join(result.Explicit, ",") != "year,month" ||
+		result.Period == nil || !result.Period.End.Equal(time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Wrong precision: %+v", response.Result)
+	}
+
+	_, response = serve(t, "POST", "/api/parse", `{"text": "Q3"}`)
+	if response.Result == nil || response.Result.Range == nil || response.Result.Kind != "range" {
+		t.Errorf("Expected a range: %+v", response.Result)
+	}
+}
+
+func TestHandlerParseErrors(t *testing.T) {
+	testCases := []struct {
+		method string
+		body   string
+		status int
+		code   string
+	}{
+		{"GET", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{"POST", `{"text": `, http.StatusBadRequest, "invalid_request"},
+		{"POST", `{"text": "tomorrow", "zone": "Mars/Olympus"}`, http.StatusBadRequest, "invalid_zone"},
+		{"POST", `{"text": "tomorrow", "locale": "xx"}`, http.StatusBadRequest, "invalid_locale"},
+		{"POST", `{"text": "tomorrow", "reference": "today"}`, http.StatusBadRequest, "invalid_reference"},
+		{"POST", `{"text": "hello world"}`, http.StatusUnprocessableEntity, "no_date"},
+		{"POST", `{"text": "june 45th"}`, http.StatusUnprocessableEntity, "day_out_of_range"},
+		{"POST", `{"text": "june 1st blah", "strict": true}`, http.StatusUnprocessableEntity, "trailing_input"},
+	}
+
+	for _, tc := range testCases {
+		status, response := serve(t, tc.method, "/api/parse", tc.body)
+		if status != tc.status || response.Error == nil || response.Error.Code != tc.code {
+			t.Errorf("%s %q returned the wrong error. expected: %d %s actual: %d %+v", tc.method, tc.body, tc.status, tc.code, status, response.Error)
+		}
+	}
+
+	// the offending word is located in the text
+	_, response := serve(t, "POST", "/api/parse", `{"text": "june 1st blah", "strict": true}`)
+	if response.Error.Start == nil || *response.Error.Start != 9 || *response.Error.End != 13 {
+		t.Errorf("Wrong span for the offending word: %+v", response.Error)
+	}
+}
+
+func TestHandlerExtract(t *testing.T) {
+	status, response := serve(t, "POST", "/api/extract", `{"text": "met on june 1st and again next tuesday"}`)
+	if status != http.StatusOK || len(response.Matches) != 2 {
+		t.Fatalf("Unexpected response %d: %+v", status, response)
+	}
+
+	match := response.Matches[1]
+	if match.Text != "next tuesday" || *match.Start != 26 || *match.End != 38 || match.Kind != "offset" {
+		t.Errorf("Wrong match: %+v", match)
+	}
+
+	status, response = serve(t, "POST", "/api/extract", `{"text": ""}`)
+	if status != http.StatusOK || len(response.Matches) != 0 {
+		t.Errorf("Expected no matches: %+v", response)
+	}
+}
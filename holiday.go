@@ -0,0 +1,202 @@
+package datelp
+
+import (
+	"strings"
+	"time"
+)
+
+// HolidayRule returns the month and day that a holiday falls on in a year
+type HolidayRule func(year int) (time.Month, int)
+
+// Holiday is a named day whose date is computed from the year, such as
+// christmas or thanksgiving
+type Holiday struct {
+	Names []string // every way that the holiday is written, in lower case. eg: "xmas"
+	Rule  HolidayRule
+}
+
+// Name returns the name that the holiday is listed under
+func (h Holiday) Name() string {
+	if len(h.Names) == 0 {
+		return ""
+	}
+
+	return h.Names[0]
+}
+
+// In returns midnight of the holiday in year
+func (h Holiday) In(year int, location *time.Location) time.Time {
+	month, day := h.Rule(year)
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
+}
+
+// FixedHoliday falls on the same day every year, such as MONTH_DECEMBER, 25
+func FixedHoliday(month, day int) HolidayRule {
+	return func(year int) (time.Month, int) {
+		m, _ := ConstantToMonth(month)
+		return m, day
+	}
+}
+
+// WeekdayHoliday falls on a weekday counted inside of a month, such as the 4th
+// thursday of november. Negative ordinals count from the end of the month, so
+// that -1 is the last monday of may.
+func WeekdayHoliday(ordinal, weekday, month int) HolidayRule {
+	return func(year int) (time.Month, int) {
+		m, _ := ConstantToMonth(month)
+		date, _ := DateContext{
+			ordinal: ordinal,
+			unit:    INTERVAL_WEEKDAY,
+			weekday: weekday,
+			period:  INTERVAL_MONTH,
+		}.compileRelative(time.Date(year, m, 1, 0, 0, 0, 0, time.UTC))
+
+		return date.Month(), date.Day()
+	}
+}
+
+// EasterHoliday falls a number of days after easter sunday, such as -2 for
+// good friday
+func EasterHoliday(days int) HolidayRule {
+	return func(year int) (time.Month, int) {
+		date := easter(year).AddDate(0, 0, days)
+		return date.Month(), date.Day()
+	}
+}
+
+// easter returns easter sunday of the gregorian calendar, using the anonymous
+// gregorian computus
+func easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// BuiltinHolidays are the holidays that every classifier recognizes unless it
+// is given a calendar of its own
+var BuiltinHolidays = []Holiday{
+	{[]string{"new year's day", "new years day", "new year's", "new years", "new year"}, FixedHoliday(MONTH_JANUARY, 1)},
+	{[]string{"martin luther king day", "martin luther king jr day", "mlk day"}, WeekdayHoliday(3, WEEKDAY_MONDAY, MONTH_JANUARY)},
+	{[]string{"valentine's day", "valentines day", "valentine's", "valentines"}, FixedHoliday(MONTH_FEBRUARY, 14)},
+	{[]string{"presidents day", "president's day", "presidents' day"}, WeekdayHoliday(3, WEEKDAY_MONDAY, MONTH_FEBRUARY)},
+	{[]string{"good friday"}, EasterHoliday(-2)},
+	{[]string{"easter", "easter sunday"}, EasterHoliday(0)},
+	{[]string{"easter monday"}, EasterHoliday(1)},
+	{[]string{"mother's day", "mothers day"}, WeekdayHoliday(2, WEEKDAY_SUNDAY, MONTH_MAY)},
+	{[]string{"memorial day"}, WeekdayHoliday(-1, WEEKDAY_MONDAY, MONTH_MAY)},
+	{[]string{"father's day", "fathers day"}, WeekdayHoliday(3, WEEKDAY_SUNDAY, MONTH_JUNE)},
+	{[]string{"independence day", "fourth of july", "4th of july"}, FixedHoliday(MONTH_JULY, 4)},
+	{[]string{"labor day", "labour day"}, WeekdayHoliday(1, WEEKDAY_MONDAY, MONTH_SEPTEMBER)},
+	{[]string{"halloween"}, FixedHoliday(MONTH_OCTOBER, 31)},
+	{[]string{"veterans day", "veteran's day"}, FixedHoliday(MONTH_NOVEMBER, 11)},
+	{[]string{"thanksgiving", "thanksgiving day"}, WeekdayHoliday(4, WEEKDAY_THURSDAY, MONTH_NOVEMBER)},
+	{[]string{"christmas eve", "xmas eve"}, FixedHoliday(MONTH_DECEMBER, 24)},
+	{[]string{"christmas", "christmas day", "xmas"}, FixedHoliday(MONTH_DECEMBER, 25)},
+	{[]string{"boxing day"}, FixedHoliday(MONTH_DECEMBER, 26)},
+	{[]string{"new year's eve", "new years eve", "nye"}, FixedHoliday(MONTH_DECEMBER, 31)},
+}
+
+// Holidays is the calendar used by classifiers which were not given one with
+// WithHolidays. Holidays added to it are recognized by every such classifier.
+var Holidays = NewHolidayCalendar(BuiltinHolidays...)
+
+// HolidayCalendar is a registry of the holidays that a classifier recognizes.
+// It is not safe to add holidays while the calendar is used for parsing.
+type HolidayCalendar struct {
+	holidays []Holiday
+}
+
+// NewHolidayCalendar returns a calendar of the given holidays. Teams that
+// only want to add to the built in holidays can start from BuiltinHolidays.
+func NewHolidayCalendar(holidays ...Holiday) *HolidayCalendar {
+	hc := &HolidayCalendar{}
+	hc.Add(holidays...)
+
+	return hc
+}
+
+// Add registers holidays with the calendar. A holiday that shares a name with
+// one already registered takes its place for that name.
+func (hc *HolidayCalendar) Add(holidays ...Holiday) {
+	hc.holidays = append(append([]Holiday{}, holidays...), hc.holidays...)
+}
+
+// Lookup returns the holiday written as name
+func (hc *HolidayCalendar) Lookup(name string) (Holiday, bool) {
+	name = strings.ToLower(name)
+	for _, holiday := range hc.holidays {
+		for _, known := range holiday.Names {
+			if known == name {
+				return holiday, true
+			}
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// On returns the holiday that falls on the calendar day of t, if any
+func (hc *HolidayCalendar) On(t time.Time) (Holiday, bool) {
+	year, month, day := t.Date()
+	for _, holiday := range hc.holidays {
+		if m, d := holiday.Rule(year); m == month && d == day {
+			return holiday, true
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// ClassifyAsHoliday classifies the name of a holiday and returns it along with
+// the number of words used. The longest name that matches wins, so that "new
+// year's eve" is not read as "new year's".
+func (hc *HolidayCalendar) ClassifyAsHoliday(i Iterator) (Holiday, int, error) {
+	match := Holiday{}
+	count := 0
+
+	current := i.Current()
+	for _, holiday := range hc.holidays {
+		for _, name := range holiday.Names {
+			// only names which start with the current word are split
+			if !strings.HasPrefix(name, current) {
+				continue
+			}
+
+			words := strings.Fields(name)
+			if len(words) <= count {
+				continue
+			}
+
+			matched := true
+			for n, word := range words {
+				if next, err := i.NextNth(n); err != nil || next != word {
+					matched = false
+					break
+				}
+			}
+
+			if matched {
+				match, count = holiday, len(words)
+			}
+		}
+	}
+
+	if count == 0 {
+		return Holiday{}, 0, newParseError(ErrUnrecognized, i, "holiday")
+	}
+
+	return match, count, nil
+}
@@ -0,0 +1,100 @@
+package datelp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	testCases := map[int][2]int{
+		2015: {4, 5},
+		2016: {3, 27},
+		2019: {4, 21},
+		2024: {3, 31},
+		2038: {4, 25},
+	}
+
+	for year, date := range testCases {
+		expected := time.Date(year, time.Month(date[0]), date[1], 0, 0, 0, 0, time.UTC)
+		if actual := easter(year); !actual.Equal(expected) {
+			t.Errorf("Wrong easter for %d. expected: %s actual: %s", year, expected, actual)
+		}
+	}
+}
+
+func TestHolidayRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		year     int
+		expected time.Time
+	}{
+		{"thanksgiving", 2015, time.Date(2015, time.November, 26, 0, 0, 0, 0, time.UTC)},
+		{"memorial day", 2015, time.Date(2015, time.May, 25, 0, 0, 0, 0, time.UTC)},
+		{"labor day", 2016, time.Date(2016, time.September, 5, 0, 0, 0, 0, time.UTC)},
+		{"good friday", 2016, time.Date(2016, time.March, 25, 0, 0, 0, 0, time.UTC)},
+		{"Christmas", 2015, time.Date(2015, time.December, 25, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		holiday, exists := Holidays.Lookup(tc.name)
+		if !exists {
+			t.Fatalf("Holiday %q was not found", tc.name)
+		}
+
+		if actual := holiday.In(tc.year, time.UTC); !actual.Equal(tc.expected) {
+			t.Errorf("Wrong date for %s %d. expected: %s actual: %s", tc.name, tc.year, tc.expected, actual)
+		}
+	}
+}
+
+func TestClassifyAsHoliday(t *testing.T) {
+	testCases := []struct {
+		input string
+		name  string
+		count int
+	}{
+		{"christmas", "christmas", 1},
+		{"christmas eve", "christmas eve", 2},
+		{"new year's eve party", "new year's eve", 3},
+		{"new years", "new year's day", 2},
+		{"fourth of july", "independence day", 3},
+	}
+
+	for _, tc := range testCases {
+		holiday, count, err := Holidays.ClassifyAsHoliday(newWordIterator(tc.input))
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+			continue
+		}
+
+		if holiday.Name() != tc.name || count != tc.count {
+			t.Errorf("\"%s\" classified wrong. expected: %s/%d actual: %s/%d", tc.input, tc.name, tc.count, holiday.Name(), count)
+		}
+	}
+
+	if _, _, err := Holidays.ClassifyAsHoliday(newWordIterator("tuesday")); err == nil {
+		t.Errorf("Expected an error for a word which is not a holiday")
+	}
+}
+
+func TestHolidayCalendarAdd(t *testing.T) {
+	calendar := NewHolidayCalendar(BuiltinHolidays...)
+	calendar.Add(
+		Holiday{[]string{"company offsite", "offsite"}, FixedHoliday(MONTH_JUNE, 12)},
+		Holiday{[]string{"christmas"}, FixedHoliday(MONTH_JANUARY, 7)},
+	)
+
+	if holiday, exists := calendar.Lookup("offsite"); !exists || holiday.Name() != "company offsite" {
+		t.Errorf("Added holiday was not found")
+	}
+
+	// the added christmas takes the place of the built in one
+	if holiday, _ := calendar.Lookup("christmas"); !holiday.In(2015, time.UTC).Equal(time.Date(2015, time.January, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Added holiday did not replace the built in one")
+	}
+
+	// the default calendar is left alone
+	if _, exists := Holidays.Lookup("offsite"); exists {
+		t.Errorf("Adding to a calendar changed the default calendar")
+	}
+}
@@ -0,0 +1,446 @@
+package datelp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the styles that Humanize writes a date in
+const (
+	STYLE_CALENDAR = iota << 1 // tomorrow, next tuesday or june 1st
+	STYLE_RELATIVE             // in 3 days or 2 hours ago
+	STYLE_ABSOLUTE             // june 1st 2015
+)
+
+// the suffixes that turn the name of an interval or weekday into its plural,
+// such as the "s" of "days" and the "en" of "wochen". Months have none, since
+// the "e" of "june" would make a plural of "jun".
+var (
+	intervalSuffixes = []string{"s", "es", "e", "en", "n"}
+	weekdaySuffixes  = []string{"s", "es"}
+)
+
+// HumanizeOption configures how Humanize writes a date
+type HumanizeOption func(*humanizer)
+
+// WithGranularity sets the interval that the date is written to the nearest
+// of: INTERVAL_YEAR, INTERVAL_MONTH, INTERVAL_WEEK, INTERVAL_DAY,
+// INTERVAL_HOUR or INTERVAL_MINUTE. By default dates at midnight are written
+// to the day and any other to the minute.
+func WithGranularity(interval int) HumanizeOption {
+	return func(h *humanizer) {
+		h.granularity = interval
+	}
+}
+
+// WithStyle sets the style that the date is written in, STYLE_CALENDAR by
+// default
+func WithStyle(style int) HumanizeOption {
+	return func(h *humanizer) {
+		h.style = style
+	}
+}
+
+type humanizer struct {
+	language    *Language
+	granularity int
+	style       int
+
+	ref    time.Time
+	target time.Time // the date truncated to the granularity
+}
+
+// Humanize writes t in English relative to ref, see Language.Humanize
+func Humanize(t, ref time.Time, options ...HumanizeOption) string {
+	return English.Humanize(t, ref, options...)
+}
+
+// Humanize writes t as a phrase relative to ref, such as "tomorrow", "next
+// tuesday", "3 weeks ago" or "june 1st". The words come from the tables of the
+// language, and a phrase is only written once parsing it at ref returns t
+// truncated to the granularity, so that humanized dates can be parsed back.
+func (l *Language) Humanize(t, ref time.Time, options ...HumanizeOption) string {
+	t = t.In(ref.Location())
+
+	h := &humanizer{language: l, granularity: INTERVAL_MINUTE, style: STYLE_CALENDAR, ref: ref}
+	if t.Equal(truncate(t, INTERVAL_DAY)) {
+		h.granularity = INTERVAL_DAY
+	}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	h.target = truncate(t, h.granularity)
+
+	candidates := h.candidates()
+	for _, candidate := range candidates {
+		if h.parses(candidate) {
+			return candidate
+		}
+	}
+
+	/* the absolute date, which is the last candidate, always parses back
+	unless the tables of the language are missing words */
+	return candidates[len(candidates)-1]
+}
+
+// parses returns whether the phrase is parsed back into the target
+func (h *humanizer) parses(phrase string) bool {
+	classifier := NewClassifier(WithReference(h.ref), WithLanguage(h.language), WithStrict())
+	result, err := classifier.Parse(NewWordIterator(strings.NewReader(phrase)))
+	if err != nil || result.Kind == KIND_RANGE || result.Kind == KIND_RECURRENCE {
+		return false
+	}
+
+	return truncate(result.Date.In(h.ref.Location()), h.granularity).Equal(h.target)
+}
+
+// candidates returns the phrases for the target in order of preference, the
+// last of which is the absolute date
+func (h *humanizer) candidates() []string {
+	phrases := make([]string, 0)
+
+	switch h.style {
+	case STYLE_CALENDAR:
+		phrases = append(phrases, h.calendar()...)
+	case STYLE_RELATIVE:
+		phrases = append(phrases, h.counts())
+	}
+
+	candidates := make([]string, 0)
+	for _, phrase := range append(phrases, h.absolute()) {
+		if phrase != "" {
+			candidates = append(candidates, phrase)
+		}
+	}
+
+	return candidates
+}
+
+// calendar returns the phrases which name the target by its day, week, month
+// or year, such as "tomorrow at 3pm", "next week" or "june"
+func (h *humanizer) calendar() []string {
+	l := h.language
+	candidates := make([]string, 0)
+
+	switch h.granularity {
+	case INTERVAL_YEAR, INTERVAL_MONTH, INTERVAL_WEEK:
+		candidates = append(candidates, h.periods(h.granularity)...)
+		if h.granularity == INTERVAL_MONTH && h.target.Year() == h.ref.Year() {
+			candidates = append(candidates, h.month())
+		}
+
+		return append(candidates, h.counts())
+	case INTERVAL_HOUR, INTERVAL_MINUTE:
+		/* times within a few hours of the reference read better as a
+		count of hours and minutes than as a clock */
+		if d := h.target.Sub(h.ref); d > -12*time.Hour && d < 12*time.Hour {
+			candidates = append(candidates, h.counts())
+		}
+	}
+
+	days := make([]string, 0)
+	if synonym, ok := h.synonym(); ok {
+		days = append(days, synonym)
+	}
+
+	weekday := name(l.Weekdays[weekdayConstant(h.target.Weekday())], weekdaySuffixes)
+	days = append(days, weekday, h.layout(l.Layouts.Next, INTERVAL_WEEKDAY, weekday), h.layout(l.Layouts.Last, INTERVAL_WEEKDAY, weekday))
+
+	if h.granularity == INTERVAL_DAY {
+		if weeks := h.calendarDays() / 7; weeks != 0 && weeks*7 == h.calendarDays() && weeks >= -4 && weeks <= 4 {
+			days = append(days, h.offset(offsetTerm{count: abs(weeks), interval: INTERVAL_WEEK}))
+		}
+	}
+
+	if h.target.Year() == h.ref.Year() {
+		days = append(days, h.date())
+	}
+
+	for _, day := range days {
+		if day != "" {
+			candidates = append(candidates, h.clock(day))
+		}
+	}
+
+	return candidates
+}
+
+// periods returns the phrases for the current, next and last period, such as
+// "this week"
+func (h *humanizer) periods(interval int) []string {
+	l := h.language
+	word := name(l.Intervals[interval], intervalSuffixes)
+
+	return []string{
+		h.layout(l.Layouts.This, interval, word),
+		h.layout(l.Layouts.Next, interval, word),
+		h.layout(l.Layouts.Last, interval, word),
+	}
+}
+
+// synonym returns the word for yesterday, today or tomorrow when the target
+// falls on one of them
+func (h *humanizer) synonym() (string, bool) {
+	synonyms := map[int]int{-1: SYNONYM_YESTERDAY, 0: SYNONYM_TODAY, 1: SYNONYM_TOMORROW}
+	synonym, exists := synonyms[h.calendarDays()]
+	if !exists {
+		return "", false
+	}
+
+	word := name(h.language.Synonyms[synonym], nil)
+	return word, word != ""
+}
+
+// counts returns the difference between the reference and the target as a
+// count of intervals, such as "in 2 hours and 13 minutes", or an empty string
+// when the two are equal
+func (h *humanizer) counts() string {
+	from, to := truncate(h.ref, h.granularity), h.target
+	if to.Before(from) {
+		from, to = to, from
+	}
+
+	terms := make([]offsetTerm, 0)
+	switch h.granularity {
+	case INTERVAL_HOUR, INTERVAL_MINUTE:
+		d := to.Sub(from)
+		terms = append(terms,
+			offsetTerm{count: int(d / (24 * time.Hour)), interval: INTERVAL_DAY},
+			offsetTerm{count: int(d % (24 * time.Hour) / time.Hour), interval: INTERVAL_HOUR},
+			offsetTerm{count: int(d % time.Hour / time.Minute), interval: INTERVAL_MINUTE})
+	case INTERVAL_WEEK:
+		terms = append(terms, offsetTerm{count: calendarDays(from, to) / 7, interval: INTERVAL_WEEK})
+	default:
+		months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+		if from.AddDate(0, months, 0).After(to) {
+			months--
+		}
+
+		days := calendarDays(from.AddDate(0, months, 0), to)
+		terms = append(terms,
+			offsetTerm{count: months / 12, interval: INTERVAL_YEAR},
+			offsetTerm{count: months % 12, interval: INTERVAL_MONTH})
+		if days%7 == 0 && months == 0 {
+			terms = append(terms, offsetTerm{count: days / 7, interval: INTERVAL_WEEK})
+		} else {
+			terms = append(terms, offsetTerm{count: days, interval: INTERVAL_DAY})
+		}
+	}
+
+	written := make([]offsetTerm, 0)
+	for _, term := range terms {
+		if term.count != 0 {
+			written = append(written, term)
+		}
+	}
+
+	if len(written) == 0 {
+		return ""
+	}
+
+	return h.offset(written...)
+}
+
+// offset writes counts of intervals before or after the reference, such as
+// "3 weeks ago"
+func (h *humanizer) offset(terms ...offsetTerm) string {
+	l := h.language
+	words := make([]string, 0)
+
+	for _, term := range terms {
+		singular := name(l.Intervals[term.interval], intervalSuffixes)
+		word := singular
+		if term.count != 1 {
+			word = plural(l.Intervals[term.interval], singular, intervalSuffixes)
+		}
+
+		words = append(words, fmt.Sprintf("%d %s", term.count, word))
+	}
+
+	counts := strings.Join(words, " "+l.Layouts.And+" ")
+	if h.target.Before(h.ref) {
+		return fmt.Sprintf(l.Layouts.Past, counts)
+	}
+
+	return fmt.Sprintf(l.Layouts.Future, counts)
+}
+
+// absolute writes the target along with its year, such as "june 1st 2015"
+func (h *humanizer) absolute() string {
+	l := h.language
+
+	switch h.granularity {
+	case INTERVAL_YEAR, INTERVAL_MONTH:
+		return fmt.Sprintf(l.Layouts.Year, h.month(), h.target.Year())
+	}
+
+	return h.clock(fmt.Sprintf(l.Layouts.Year, h.date(), h.target.Year()))
+}
+
+// month returns the name of the month of the target
+func (h *humanizer) month() string {
+	return name(h.language.Months[monthConstant(h.target.Month())], nil)
+}
+
+// date writes the day and month of the target, such as "june 1st"
+func (h *humanizer) date() string {
+	l := h.language
+	day := strconv.Itoa(h.target.Day())
+	if l.Layouts.Day != nil {
+		day = l.Layouts.Day(h.target.Day())
+	}
+
+	return fmt.Sprintf(l.Layouts.Date, day, h.month())
+}
+
+// clock adds the time of day of the target to a day, when the granularity is
+// finer than a day
+func (h *humanizer) clock(day string) string {
+	l := h.language
+	layout := l.Layouts.Clock
+
+	switch h.granularity {
+	case INTERVAL_HOUR, INTERVAL_MINUTE:
+		if h.target.Minute() == 0 {
+			layout = l.Layouts.Hour
+		}
+	default:
+		return day
+	}
+
+	return fmt.Sprintf(l.Layouts.At, day, h.target.Format(layout))
+}
+
+// layout fills in the layout of an interval, or of INTERVAL_WEEKDAY when the
+// interval has none. An empty string is returned when neither exists.
+func (h *humanizer) layout(layouts map[int]string, interval int, word string) string {
+	layout, exists := layouts[interval]
+	if !exists {
+		layout, exists = layouts[INTERVAL_WEEKDAY]
+	}
+
+	if !exists || word == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(layout, word)
+}
+
+// calendarDays returns the number of calendar days from the reference to the
+// target
+func (h *humanizer) calendarDays() int {
+	return calendarDays(h.ref, h.target)
+}
+
+// calendarDays returns the number of midnights between from and to, negative
+// when to is before from
+func calendarDays(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// name returns the word of a table entry that Humanize writes: the first one
+// listed which is neither the plural nor an abbreviation of another, such as
+// "tuesday" out of "t", "tuesday", "tues" and "tuesdays"
+func name(words, suffixes []string) string {
+	singulars := make([]string, 0)
+	for _, word := range words {
+		if !strings.Contains(word, " ") && !isPlural(words, word, suffixes) {
+			singulars = append(singulars, word)
+		}
+	}
+
+	for _, word := range singulars {
+		abbreviation := false
+		for _, other := range singulars {
+			if other != word && strings.HasPrefix(other, word) {
+				abbreviation = true
+				break
+			}
+		}
+
+		if !abbreviation {
+			return word
+		}
+	}
+
+	return ""
+}
+
+// plural returns the longest plural of singular listed in words, or singular
+// itself when the language writes both the same way
+func plural(words []string, singular string, suffixes []string) string {
+	match := singular
+	for _, word := range words {
+		for _, suffix := range suffixes {
+			if word == singular+suffix && len(word) > len(match) {
+				match = word
+			}
+		}
+	}
+
+	return match
+}
+
+// isPlural returns whether word is another of the words with a plural suffix
+func isPlural(words []string, word string, suffixes []string) bool {
+	for _, other := range words {
+		for _, suffix := range suffixes {
+			if other+suffix == word {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// englishOrdinal writes the day of a month with its english suffix, eg: 1st
+func englishOrdinal(day int) string {
+	suffix := "th"
+	switch {
+	case day%100 >= 11 && day%100 <= 13:
+	case day%10 == 1:
+		suffix = "st"
+	case day%10 == 2:
+		suffix = "nd"
+	case day%10 == 3:
+		suffix = "rd"
+	}
+
+	return strconv.Itoa(day) + suffix
+}
+
+func weekdayConstant(weekday time.Weekday) int {
+	for _, constant := range weekdayOrder {
+		if w, _ := ConstantToWeekday(constant); w == weekday {
+			return constant
+		}
+	}
+
+	return WEEKDAY_SUNDAY
+}
+
+func monthConstant(month time.Month) int {
+	for _, constant := range monthOrder {
+		if m, _ := ConstantToMonth(constant); m == month {
+			return constant
+		}
+	}
+
+	return MONTH_JANUARY
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,146 @@
+package datelp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHumanize(t *testing.T) {
+	// a wednesday
+	ref := time.Date(2015, time.May, 27, 10, 30, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2015, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		date     time.Time
+		options  []HumanizeOption
+		expected string
+	}{
+		{at(time.May, 27, 0, 0), nil, "today"},
+		{at(time.May, 28, 0, 0), nil, "tomorrow"},
+		{at(time.May, 26, 0, 0), nil, "yesterday"},
+		{at(time.May, 29, 0, 0), nil, "friday"},
+		{at(time.June, 2, 0, 0), nil, "next tuesday"},
+		{at(time.May, 21, 0, 0), nil, "last thursday"},
+		{at(time.May, 6, 0, 0), nil, "3 weeks ago"},
+		{at(time.June, 17, 0, 0), nil, "in 3 weeks"},
+		{at(time.June, 1, 0, 0), nil, "next monday"},
+		{at(time.June, 11, 0, 0), nil, "june 11th"},
+		{at(time.December, 22, 0, 0), nil, "december 22nd"},
+		{time.Date(2016, time.June, 1, 0, 0, 0, 0, time.UTC), nil, "june 1st 2016"},
+		{at(time.May, 27, 12, 30), nil, "in 2 hours"},
+		{at(time.May, 27, 8, 17), nil, "2 hours and 13 minutes ago"},
+		{at(time.May, 27, 10, 30), nil, "today at 10:30am"},
+		{at(time.May, 28, 15, 0), nil, "tomorrow at 3pm"},
+		{at(time.June, 1, 9, 5), nil, "next monday at 9:05am"},
+		{at(time.June, 20, 9, 5), nil, "june 20th at 9:05am"},
+
+		// granularity
+		{at(time.May, 28, 15, 20), []HumanizeOption{WithGranularity(INTERVAL_DAY)}, "tomorrow"},
+		{at(time.May, 27, 15, 20), []HumanizeOption{WithGranularity(INTERVAL_HOUR)}, "in 5 hours"},
+		{at(time.June, 3, 0, 0), []HumanizeOption{WithGranularity(INTERVAL_WEEK)}, "next week"},
+		{at(time.June, 20, 0, 0), []HumanizeOption{WithGranularity(INTERVAL_WEEK)}, "in 3 weeks"},
+		{at(time.May, 2, 0, 0), []HumanizeOption{WithGranularity(INTERVAL_MONTH)}, "this month"},
+		{at(time.August, 2, 0, 0), []HumanizeOption{WithGranularity(INTERVAL_MONTH)}, "august"},
+		{time.Date(2014, time.March, 2, 0, 0, 0, 0, time.UTC), []HumanizeOption{WithGranularity(INTERVAL_MONTH)}, "1 year and 2 months ago"},
+		{time.Date(2016, time.March, 2, 0, 0, 0, 0, time.UTC), []HumanizeOption{WithGranularity(INTERVAL_YEAR)}, "next year"},
+
+		// styles
+		{at(time.June, 1, 0, 0), []HumanizeOption{WithStyle(STYLE_RELATIVE)}, "in 5 days"},
+		{at(time.July, 30, 0, 0), []HumanizeOption{WithStyle(STYLE_RELATIVE)}, "in 2 months and 3 days"},
+		{at(time.May, 28, 15, 0), []HumanizeOption{WithStyle(STYLE_RELATIVE)}, "in 1 day and 4 hours and 30 minutes"},
+		{at(time.May, 28, 0, 0), []HumanizeOption{WithStyle(STYLE_ABSOLUTE)}, "may 28th 2015"},
+		{at(time.May, 28, 15, 0), []HumanizeOption{WithStyle(STYLE_ABSOLUTE)}, "may 28th 2015 at 3pm"},
+	}
+
+	for _, tc := range testCases {
+		actual := Humanize(tc.date, ref, tc.options...)
+		if actual != tc.expected {
+			t.Errorf("Humanized %s wrong. expected: %q actual: %q", tc.date, tc.expected, actual)
+		}
+	}
+}
+
+func TestHumanizeLanguages(t *testing.T) {
+	ref := time.Date(2015, time.May, 27, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		language *Language
+		date     time.Time
+		expected string
+	}{
+		{Spanish, time.Date(2015, time.May, 28, 0, 0, 0, 0, time.UTC), "mañana"},
+		{Spanish, time.Date(2015, time.June, 2, 0, 0, 0, 0, time.UTC), "martes próximo"},
+		{Spanish, time.Date(2015, time.May, 6, 0, 0, 0, 0, time.UTC), "hace 3 semanas"},
+		{Spanish, time.Date(2016, time.June, 1, 15, 15, 0, 0, time.UTC), "1 de junio de 2016 a las 15:15"},
+		{French, time.Date(2015, time.May, 19, 0, 0, 0, 0, time.UTC), "mardi dernier"},
+		{French, time.Date(2015, time.May, 27, 12, 30, 0, 0, time.UTC), "dans 2 heures"},
+		{French, time.Date(2015, time.June, 20, 0, 0, 0, 0, time.UTC), "20 juin"},
+		{German, time.Date(2015, time.May, 27, 8, 30, 0, 0, time.UTC), "vor 2 stunden"},
+		{German, time.Date(2015, time.June, 17, 0, 0, 0, 0, time.UTC), "in 3 wochen"},
+		{German, time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC), "1. märz 2016"},
+	}
+
+	for _, tc := range testCases {
+		actual := tc.language.Humanize(tc.date, ref)
+		if actual != tc.expected {
+			t.Errorf("%s humanized %s wrong. expected: %q actual: %q", tc.language.Name, tc.date, tc.expected, actual)
+		}
+	}
+}
+
+func TestHumanizeRoundTrip(t *testing.T) {
+	ref := time.Date(2015, time.May, 27, 10, 30, 0, 0, time.UTC)
+	styles := []int{STYLE_CALENDAR, STYLE_RELATIVE, STYLE_ABSOLUTE}
+	granularities := []int{INTERVAL_MINUTE, INTERVAL_HOUR, INTERVAL_DAY, INTERVAL_WEEK, INTERVAL_MONTH, INTERVAL_YEAR}
+
+	for _, language := range []*Language{English, Spanish, French, German} {
+		for hours := -24 * 400; hours <= 24*400; hours += 24*29 + 7 {
+			date := ref.Add(time.Duration(hours)*time.Hour + 13*time.Minute)
+
+			for _, style := range styles {
+				for _, granularity := range granularities {
+					phrase := language.Humanize(date, ref, WithStyle(style), WithGranularity(granularity))
+
+					classifier := NewClassifier(WithReference(ref), WithLanguage(language))
+					result, err := classifier.Parse(NewWordIterator(strings.NewReader(phrase)))
+					if err != nil {
+						t.Errorf("%s: %q of %s did not parse: %s", language.Name, phrase, date, err)
+						continue
+					}
+
+					if expected := truncate(date, granularity); !truncate(result.Date, granularity).Equal(expected) {
+						t.Errorf("%s: %q parsed as %s instead of %s", language.Name, phrase, result.Date, expected)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	testCases := []struct {
+		words            []string
+		suffixes         []string
+		singular, plural string
+	}{
+		{English.Weekdays[WEEKDAY_TUESDAY], weekdaySuffixes, "tuesday", "tuesdays"},
+		{English.Months[MONTH_JUNE], nil, "june", "june"},
+		{English.Intervals[INTERVAL_HOUR], intervalSuffixes, "hour", "hours"},
+		{Spanish.Weekdays[WEEKDAY_WEDNESDAY], weekdaySuffixes, "miércoles", "miércoles"},
+		{Spanish.Intervals[INTERVAL_MONTH], intervalSuffixes, "mes", "meses"},
+		{French.Intervals[INTERVAL_YEAR], intervalSuffixes, "année", "années"},
+		{French.Intervals[INTERVAL_MONTH], intervalSuffixes, "mois", "mois"},
+		{German.Months[MONTH_MARCH], nil, "märz", "märz"},
+		{German.Intervals[INTERVAL_DAY], intervalSuffixes, "tag", "tagen"},
+	}
+
+	for _, tc := range testCases {
+		singular := name(tc.words, tc.suffixes)
+		if actual := plural(tc.words, singular, tc.suffixes); singular != tc.singular || actual != tc.plural {
+			t.Errorf("Wrong name for %v. expected: %s/%s actual: %s/%s", tc.words, tc.singular, tc.plural, singular, actual)
+		}
+	}
+}
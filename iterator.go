@@ -2,8 +2,10 @@ package datelp
 
 import (
 	"bufio"
-	"errors"
 	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
 )
 
 type Iterator interface {
@@ -17,36 +19,63 @@ type Iterator interface {
 	Move() error
 	MoveN(int) error
 	End() bool
+
+	// Index returns the position of the current token in the stream and
+	// Span the byte offsets [start, end) of the current token in the input
+	Index() int
+	Span() (int, int)
+
+	// Separated returns whether punctuation such as a full stop or an
+	// opening bracket comes between the current token and the one before it
+	Separated() bool
+}
+
+// Token is a single word of the input along with the byte offsets it was
+// read from. Text is the normalized form of the word that is classified and
+// Raw the word as it was written. Separated is set when punctuation which ends
+// a sentence or opens a clause comes between the token and the one before it.
+type Token struct {
+	Text      string
+	Raw       string
+	Start     int
+	End       int
+	Separated bool
 }
 
 type WordIterator struct {
-	words []string
-	index int
+	tokens []Token
+	index  int
 }
 
 func NewWordIterator(input io.Reader) Iterator {
-	scanner := bufio.NewScanner(input)
-	scanner.Split(bufio.ScanWords)
+	data, _ := ioutil.ReadAll(input)
 
-	words := make([]string, 0)
-	for scanner.Scan() {
-		word := scanner.Text()
-		words = append(words, word)
+	return &WordIterator{
+		tokens: Tokenize(string(data)),
+		index:  0,
+	}
+}
+
+// newTokenIterator builds an iterator over words that have already been split
+func newTokenIterator(words []string) *WordIterator {
+	tokens := make([]Token, len(words))
+	for index, word := range words {
+		tokens[index] = Token{Text: word, Raw: word}
 	}
 
 	return &WordIterator{
-		words: words,
-		index: 0,
+		tokens: tokens,
+		index:  0,
 	}
 }
 
 func (i WordIterator) End() bool {
-	return i.index+1 >= len(i.words)
+	return i.index+1 >= len(i.tokens)
 }
 
 func (i *WordIterator) Move() error {
-	if i.index+1 >= len(i.words) {
-		return errors.New("out of range")
+	if i.index+1 >= len(i.tokens) {
+		return ErrOutOfRange
 	}
 
 	i.index += 1
@@ -54,8 +83,8 @@ func (i *WordIterator) Move() error {
 }
 
 func (i *WordIterator) MoveN(n int) error {
-	if i.index+n >= len(i.words) || i.index+n < 0 {
-		return errors.New("out of range")
+	if i.index+n >= len(i.tokens) || i.index+n < 0 {
+		return ErrOutOfRange
 	}
 
 	i.index += n
@@ -63,37 +92,275 @@ func (i *WordIterator) MoveN(n int) error {
 }
 
 func (i WordIterator) Current() string {
-	return i.words[i.index]
+	return i.tokens[i.index].Text
+}
+
+func (i WordIterator) Index() int {
+	return i.index
+}
+
+func (i WordIterator) Span() (int, int) {
+	return i.tokens[i.index].Start, i.tokens[i.index].End
+}
+
+func (i WordIterator) Separated() bool {
+	return i.tokens[i.index].Separated
 }
 
 func (i WordIterator) Next() (string, error) {
-	if i.index+1 >= len(i.words) {
-		return "", errors.New("out of range")
+	if i.index+1 >= len(i.tokens) {
+		return "", ErrOutOfRange
 	}
 
-	return i.words[i.index+1], nil
+	return i.tokens[i.index+1].Text, nil
 }
 
 func (i WordIterator) Prev() (string, error) {
 	if i.index-1 < 0 {
-		return "", errors.New("out of range")
+		return "", ErrOutOfRange
 	}
 
-	return i.words[i.index-1], nil
+	return i.tokens[i.index-1].Text, nil
 }
 
 func (i WordIterator) PrevNth(n int) (string, error) {
 	if i.index-n < 0 {
-		return "", errors.New("out of range")
+		return "", ErrOutOfRange
 	}
 
-	return i.words[i.index-n], nil
+	return i.tokens[i.index-n].Text, nil
 }
 
 func (i WordIterator) NextNth(n int) (string, error) {
-	if i.index+n >= len(i.words) {
-		return "", errors.New("out of range")
+	if i.index+n >= len(i.tokens) {
+		return "", ErrOutOfRange
+	}
+
+	return i.tokens[i.index+n].Text, nil
+}
+
+const (
+	// the number of tokens that a StreamIterator keeps on either side of
+	// the current one unless it is given another window
+	StreamWindow = 64
+
+	// the longest word or run of whitespace that a StreamIterator holds on
+	// to. Longer words can not be part of a date and are skipped, while
+	// longer runs of whitespace are kept as a single space.
+	maxWordSize = 4096
+)
+
+// StreamIterator tokenizes a reader as it is iterated instead of reading all
+// of it up front, and only holds on to a window of tokens around the current
+// one. Looking further ahead than the window, or moving back past the tokens
+// that it holds on to, returns ErrOutOfRange.
+type StreamIterator struct {
+	reader *bufio.Reader
+	window int
+
+	// tokens[0] is token number base of the stream, and gaps holds the
+	// text between each token and the one before it
+	tokens []Token
+	gaps   []string
+	base   int
+	index  int
+
+	last   Token  // the last token that was read
+	offset int    // byte offset of the reader
+	carry  string // text after the last token which belongs to the next gap
+	eof    bool
+	err    error
+}
+
+// NewStreamIterator returns an iterator over the words of input which holds
+// on to window tokens on either side of the current one. The window is never
+// smaller than the longest expression that a classifier looks ahead for.
+func NewStreamIterator(input io.Reader, window int) *StreamIterator {
+	i := &StreamIterator{
+		reader: bufio.NewReader(input),
+		window: MaxInt(window, rangeWindow),
+	}
+	i.fill(0)
+
+	return i
+}
+
+// Err returns the first error of the reader other than io.EOF
+func (i *StreamIterator) Err() error {
+	return i.err
+}
+
+// fill reads from the reader until the token at index of the stream is held,
+// and returns whether it is
+func (i *StreamIterator) fill(index int) bool {
+	for i.base+len(i.tokens) <= index && !i.eof {
+		i.read()
+	}
+
+	return index >= i.base && index < i.base+len(i.tokens)
+}
+
+// read reads the next whitespace separated word and adds its tokens
+func (i *StreamIterator) read() {
+	gap := &strings.Builder{}
+	gap.WriteString(i.carry)
+	i.carry = ""
+
+	word := &strings.Builder{}
+	start := i.offset
+	skip := false
+
+	for {
+		r, width, err := i.reader.ReadRune()
+		if err != nil {
+			if err != io.EOF {
+				i.err = err
+			}
+			i.eof = true
+			break
+		}
+		i.offset += width
+
+		if unicode.IsSpace(r) {
+			if word.Len() > 0 || skip {
+				i.carry = string(r)
+				break
+			}
+
+			if gap.Len() < maxWordSize {
+				gap.WriteRune(r)
+			}
+			start = i.offset
+			continue
+		}
+
+		if word.Len()+width > maxWordSize {
+			word.Reset()
+			skip = true
+		}
+
+		if !skip {
+			word.WriteRune(r)
+		}
+	}
+
+	if gap.Len() >= maxWordSize {
+		gap.Reset()
+		gap.WriteString(" ")
+	}
+
+	tokens := splitWord(word.String(), 0, word.Len())
+	text := word.String()
+	previous := 0
+	for _, token := range tokens {
+		gap.WriteString(text[previous:token.Start])
+		previous = token.End
+
+		token.Start += start
+		token.End += start
+		if i.base+len(i.tokens) > 0 {
+			token.Separated = separates(i.last, gap.String())
+		}
+		i.last = token
+		i.tokens = append(i.tokens, token)
+		i.gaps = append(i.gaps, gap.String())
+		gap.Reset()
+	}
+
+	// punctuation around the word, or all of it when it had no tokens,
+	// belongs to the gap before the next one
+	i.carry = gap.String() + text[previous:] + i.carry
+}
+
+// evict lets go of the tokens which fell out of the window behind the
+// current one
+func (i *StreamIterator) evict() {
+	if n := i.index - i.window - i.base; n > 0 {
+		i.tokens = i.tokens[n:]
+		i.gaps = i.gaps[n:]
+		i.base += n
+	}
+}
+
+// token returns the token n positions away from the current one
+func (i *StreamIterator) token(n int) (Token, error) {
+	if n > i.window || !i.fill(i.index+n) {
+		return Token{}, ErrOutOfRange
+	}
+
+	return i.tokens[i.index+n-i.base], nil
+}
+
+// text returns the input between the byte offsets start and end, which have
+// to fall on tokens that are still held
+func (i *StreamIterator) text(start, end int) string {
+	text := &strings.Builder{}
+	for n, token := range i.tokens {
+		if token.Start < start || token.End > end {
+			continue
+		}
+
+		if token.Start > start {
+			text.WriteString(i.gaps[n])
+		}
+		text.WriteString(token.Raw)
+	}
+
+	return text.String()
+}
+
+func (i *StreamIterator) End() bool {
+	_, err := i.token(1)
+	return err != nil
+}
+
+func (i *StreamIterator) Move() error {
+	return i.MoveN(1)
+}
+
+func (i *StreamIterator) MoveN(n int) error {
+	if n > i.window || !i.fill(i.index+n) {
+		return ErrOutOfRange
 	}
 
-	return i.words[i.index+n], nil
+	i.index += n
+	i.evict()
+	return nil
+}
+
+func (i *StreamIterator) Current() string {
+	token, _ := i.token(0)
+	return token.Text
+}
+
+func (i *StreamIterator) Index() int {
+	return i.index
+}
+
+func (i *StreamIterator) Span() (int, int) {
+	token, _ := i.token(0)
+	return token.Start, token.End
+}
+
+func (i *StreamIterator) Separated() bool {
+	token, _ := i.token(0)
+	return token.Separated
+}
+
+func (i *StreamIterator) Next() (string, error) {
+	return i.NextNth(1)
+}
+
+func (i *StreamIterator) Prev() (string, error) {
+	return i.PrevNth(1)
+}
+
+func (i *StreamIterator) PrevNth(n int) (string, error) {
+	token, err := i.token(-n)
+	return token.Text, err
+}
+
+func (i *StreamIterator) NextNth(n int) (string, error) {
+	token, err := i.token(n)
+	return token.Text, err
 }
@@ -44,3 +44,120 @@ func TestWordIteratorPrev(t *testing.T) {
 		return
 	}
 }
+
+func TestWordIteratorSpan(t *testing.T) {
+	i := newWordIterator("  hello\tbig  world ")
+
+	expected := [][2]int{{2, 7}, {8, 11}, {13, 18}}
+	for index, span := range expected {
+		start, end := i.Span()
+		if i.Index() != index || start != span[0] || end != span[1] {
+			t.Errorf("iterator returned wrong span. expected: %v actual: [%d %d]", span, start, end)
+		}
+		i.Move()
+	}
+}
+
+func TestStreamIteratorTokens(t *testing.T) {
+	testCases := []string{
+		"",
+		"hello world",
+		"  met on june 1st, and (again) next tuesday.\n",
+		"out from june 1 to june 5, back after",
+		"06/01/2015 at 3pm -- ok?",
+		"¿el próximo martes?",
+	}
+
+	for _, tc := range testCases {
+		expected := Tokenize(tc)
+		i := NewStreamIterator(strings.NewReader(tc), StreamWindow)
+
+		for index, token := range expected {
+			start, end := i.Span()
+			if i.Current() != token.Text || start != token.Start || end != token.End {
+				t.Errorf("%q returned wrong token %d. expected: %q [%d %d] actual: %q [%d %d]",
+					tc, index, token.Text, token.Start, token.End, i.Current(), start, end)
+			}
+
+			if text := i.text(token.Start, token.End); text != tc[token.Start:token.End] {
+				t.Errorf("%q returned wrong text. expected: %q actual: %q", tc, tc[token.Start:token.End], text)
+			}
+
+			if i.End() != (index == len(expected)-1) {
+				t.Errorf("%q returned wrong end for token %d", tc, index)
+			}
+			i.Move()
+		}
+
+		if _, err := i.NextNth(0); len(expected) == 0 && err == nil {
+			t.Errorf("%q should not have a current token", tc)
+		}
+	}
+}
+
+func TestStreamIteratorText(t *testing.T) {
+	input := "met on june 1st, and (again)  next tuesday."
+	i := NewStreamIterator(strings.NewReader(input), StreamWindow)
+	for !i.End() {
+		i.Move()
+	}
+
+	if text := i.text(0, len(input)); text != "met on june 1st, and (again)  next tuesday" {
+		t.Errorf("iterator returned wrong text: %q", text)
+	}
+}
+
+func TestStreamIteratorWindow(t *testing.T) {
+	words := make([]string, 100)
+	for index := range words {
+		words[index] = "word"
+	}
+
+	i := NewStreamIterator(strings.NewReader(strings.Join(words, " ")), rangeWindow)
+	if _, err := i.NextNth(rangeWindow); err != nil {
+		t.Error("iterator should look ahead as far as its window")
+	}
+
+	if _, err := i.NextNth(rangeWindow + 1); err != ErrOutOfRange {
+		t.Error("iterator should not look ahead past its window")
+	}
+
+	for n := 0; n < 5; n++ {
+		if err := i.MoveN(10); err != nil {
+			t.Errorf("iterator should have moved, got %v at %d", err, i.Index())
+		}
+	}
+
+	if _, err := i.PrevNth(rangeWindow); err != nil {
+		t.Error("iterator should hold on to its window")
+	}
+
+	if err := i.MoveN(-rangeWindow - 1); err != ErrOutOfRange || i.Index() != 50 {
+		t.Error("iterator should not move back past its window")
+	}
+
+	if err := i.MoveN(rangeWindow + 1); err != ErrOutOfRange {
+		t.Error("iterator should not move further than its window")
+	}
+
+	if len(i.tokens) > 2*rangeWindow+1 {
+		t.Errorf("iterator holds on to %d tokens", len(i.tokens))
+	}
+}
+
+func TestStreamIteratorLongWords(t *testing.T) {
+	input := "june 1st " + strings.Repeat("x", 2*maxWordSize) + strings.Repeat(" ", 2*maxWordSize) + "june 2nd"
+	i := NewStreamIterator(strings.NewReader(input), StreamWindow)
+
+	expected := []string{"june", "1st", "june", "2nd"}
+	for _, word := range expected {
+		if i.Current() != word {
+			t.Errorf("iterator returned wrong word. expected: %q actual: %q", word, i.Current())
+		}
+		i.Move()
+	}
+
+	if start, _ := i.Span(); start != len(input)-3 {
+		t.Errorf("iterator returned wrong offset %d", start)
+	}
+}
@@ -0,0 +1,187 @@
+package datelp
+
+// Language is the vocabulary that the leaf classifiers match words against.
+// Every table maps one of the package constants, such as MONTH_JUNE or
+// DIRECTION_LEFT, to each lower case word which refers to it. Because the
+// contexts collect components regardless of where they appear, a pack only
+// needs to supply words; word orders such as "el martes pasado" or "1er juin"
+// work without any further configuration.
+type Language struct {
+	Code string // ISO 639-1 code, eg: es
+	Name string
+
+	Months     map[int][]string
+	Weekdays   map[int][]string
+	Directions map[int][]string
+	Synonyms   map[int][]string
+	Intervals  map[int][]string
+
+	// Common words are skipped wherever they appear, eg: "the", "de"
+	Common []string
+
+	// Ahead words point to the future only when they introduce a count of
+	// an interval, such as the "in" of "in 3 hours" but not of "in june"
+	Ahead []string
+
+	// Ordinals maps positions counted from the end of a period to the
+	// words for them, eg: -1 to "last". Positions from the start are
+	// written as numbers.
+	Ordinals map[int][]string
+
+	// Of words link an ordinal to the period that it counts inside of,
+	// such as the "of" of "last friday of june"
+	Of []string
+
+	// Business words turn the days written next to them into business
+	// days, such as the "business" of "3 business days"
+	Business []string
+
+	// Ending words link a week to the day that it ends on, such as the
+	// "ending" of "week ending friday"
+	Ending []string
+
+	// Boundaries maps the start, middle and end of a period to the words
+	// for them, such as the "end" of "end of the month"
+	Boundaries map[int][]string
+
+	// EndOf maps periods to abbreviations for the end of them, eg:
+	// INTERVAL_MONTH to "eom"
+	EndOf map[int][]string
+
+	// Times maps seconds past midnight to the named times of day which
+	// fall on them, eg: 43200 to "noon", and OClock words follow the hour
+	// of a clock, such as the "o'clock" of "9 o'clock"
+	Times  map[int][]string
+	OClock []string
+
+	// Workweek and Weekend words name a group of weekdays at once, such as
+	// the "weekend" of "every weekend"
+	Workweek []string
+	Weekend  []string
+
+	// FiscalYear words name a fiscal year on their own and may have its
+	// number glued to them, such as "fy" or "fy17", while Fiscal words
+	// name one along with a word for the year, such as "fiscal year"
+	FiscalYear []string
+	Fiscal     []string
+
+	// From and Between words introduce a range, such as "from june 1 to
+	// june 5". The ends of a From range are separated by a To word, or an
+	// Until word when the end is excluded, while the ends of a Between
+	// range are separated by an And word. Or words join lists of weekdays
+	// along with And words.
+	From    []string
+	Between []string
+	To      []string
+	Until   []string
+	And     []string
+	Or      []string
+
+	// Every words introduce a recurrence, such as "every tuesday", and
+	// Other words skip every other one of its periods. On words introduce
+	// a plural weekday, such as "on mondays", and Frequencies map the
+	// FREQUENCY_* constants to the words for them, eg: "daily".
+	Every       []string
+	Other       []string
+	On          []string
+	Frequencies map[int][]string
+
+	// Starting and Except words modify a recurrence, such as "starting june
+	// 1st" or "except fridays". A recurrence ends at a To or Until word,
+	// such as "until june 30".
+	Starting []string
+	Except   []string
+
+	// Order is the order that the day and month of numeric dates such as
+	// 06/01/2015 are read in unless WithDateOrder picks one, eg: ORDER_DMY
+	Order int
+
+	// Numbers maps spelled out numbers and ordinals to their value. Each
+	// of them may be chained into a stem, such as "twenty three".
+	Numbers map[string]int
+
+	// Layouts arrange the words of the tables into the phrases that
+	// Humanize writes
+	Layouts Layouts
+}
+
+// Layouts are the fmt layouts that Humanize fills in with words from the
+// tables of a language. Next, Last and This are keyed by the interval that
+// they are written with, and fall back to the layout of INTERVAL_WEEKDAY so
+// that languages without gender only need the one.
+type Layouts struct {
+	Future string // a count of intervals after the reference, eg: "in %s"
+	Past   string // a count of intervals before it, eg: "%s ago"
+	And    string // joins the counts of a compound offset, eg: "and"
+
+	Next map[int]string // eg: "next %s"
+	Last map[int]string // eg: "last %s"
+	This map[int]string // eg: "this %s"
+
+	Date  string // the day and the name of the month, eg: "%[2]s %[1]s"
+	Year  string // a date or month along with its year, eg: "%s %d"
+	At    string // a date along with a time of day, eg: "%s at %s"
+	Clock string // time layout of a time of day, eg: "3:04pm"
+	Hour  string // time layout of a time on the hour, eg: "3pm"
+
+	// Day writes the day of the month, as a plain number when nil
+	Day func(day int) string
+}
+
+// the order that each table is searched in, so that a word which appears
+// in more than one entry is always classified the same way
+var (
+	monthOrder = []int{
+		MONTH_JANUARY, MONTH_FEBRUARY, MONTH_MARCH, MONTH_APRIL,
+		MONTH_MAY, MONTH_JUNE, MONTH_JULY, MONTH_AUGUST,
+		MONTH_SEPTEMBER, MONTH_OCTOBER, MONTH_NOVEMBER, MONTH_DECEMBER,
+	}
+	weekdayOrder = []int{
+		WEEKDAY_SUNDAY, WEEKDAY_MONDAY, WEEKDAY_TUESDAY, WEEKDAY_WEDNESDAY,
+		WEEKDAY_THURSDAY, WEEKDAY_FRIDAY, WEEKDAY_SATURDAY,
+	}
+	directionOrder = []int{DIRECTION_CURRENT, DIRECTION_LEFT, DIRECTION_RIGHT}
+	synonymOrder   = []int{SYNONYM_YESTERDAY, SYNONYM_TODAY, SYNONYM_TOMORROW}
+	intervalOrder  = []int{
+		INTERVAL_DAY, INTERVAL_WEEK, INTERVAL_MONTH, INTERVAL_YEAR, INTERVAL_CENTURY,
+		INTERVAL_FORTNIGHT, INTERVAL_HOUR, INTERVAL_MINUTE, INTERVAL_SECOND,
+		INTERVAL_QUARTER, INTERVAL_BUSINESS_DAY,
+	}
+	boundaryOrder  = []int{BOUNDARY_START, BOUNDARY_MIDDLE, BOUNDARY_END}
+	frequencyOrder = []int{FREQUENCY_DAILY, FREQUENCY_WEEKLY, FREQUENCY_MONTHLY, FREQUENCY_YEARLY}
+)
+
+var languages = map[string]*Language{
+	English.Code: English,
+	Spanish.Code: Spanish,
+	French.Code:  French,
+	German.Code:  German,
+}
+
+// LookupLanguage returns the built in language pack for an ISO 639-1 code
+func LookupLanguage(code string) (*Language, bool) {
+	language, exists := languages[code]
+	return language, exists
+}
+
+// isInterval returns whether the word names an interval regardless of the
+// words around it, or is part of the name of one such as "business"
+func (l *Language) isInterval(word string) bool {
+	return len(l.lookup(l.Intervals, intervalOrder, word)) > 0 || contains(l.Business, word)
+}
+
+// lookup returns every constant of the table that lists the word, in the
+// given order
+func (l *Language) lookup(table map[int][]string, order []int, word string) []int {
+	matches := make([]int, 0)
+	for _, constant := range order {
+		for _, identifier := range table[constant] {
+			if identifier == word {
+				matches = append(matches, constant)
+				break
+			}
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,152 @@
+package datelp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLanguageEndToEnd(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		language *Language
+		input    string
+		expected time.Time
+	}{
+		{Spanish, "el próximo martes", ref.AddDate(0, 0, 9)},
+		{Spanish, "el martes próximo", ref.AddDate(0, 0, 9)},
+		{Spanish, "hoy", ref},
+		{Spanish, "mañana", ref.AddDate(0, 0, 1)},
+		{Spanish, "3 de junio de 2015", time.Date(2015, time.June, 3, 0, 0, 0, 0, time.UTC)},
+		{Spanish, "la semana pasada", ref.AddDate(0, 0, -7)},
+		{Spanish, "hace 3 días", ref.AddDate(0, 0, -3)},
+		{French, "1er juin", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{French, "mardi prochain", ref.AddDate(0, 0, 9)},
+		{French, "samedi dernier", ref.AddDate(0, 0, -1)},
+		{French, "demain à 15:30", time.Date(2015, time.June, 1, 15, 30, 0, 0, time.UTC)},
+		{German, "nächsten dienstag", ref.AddDate(0, 0, 9)},
+		{German, "letzte woche", ref.AddDate(0, 0, -7)},
+		{German, "vor 3 tagen", ref.AddDate(0, 0, -3)},
+		{German, "in 2 stunden", ref.Add(2 * time.Hour)},
+		{Spanish, "en 3 días", ref.AddDate(0, 0, 3)},
+		{German, "2. juni 2015", time.Date(2015, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{English, "1st june", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{Spanish, "el último viernes de junio", time.Date(2015, time.June, 26, 0, 0, 0, 0, time.UTC)},
+		{French, "le dernier vendredi de juin", time.Date(2015, time.June, 26, 0, 0, 0, 0, time.UTC)},
+		{German, "letzten freitag im juni", time.Date(2015, time.June, 26, 0, 0, 0, 0, time.UTC)},
+		{Spanish, "el primer lunes de julio", time.Date(2015, time.July, 6, 0, 0, 0, 0, time.UTC)},
+		{Spanish, "a finales de junio", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{French, "fin du mois", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{German, "ende juni", time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{German, "anfang nächster woche", time.Date(2015, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{Spanish, "en 3 días hábiles", time.Date(2015, time.June, 3, 12, 0, 0, 0, time.UTC)},
+		{German, "in 3 werktagen", time.Date(2015, time.June, 3, 12, 0, 0, 0, time.UTC)},
+		{Spanish, "15/06/2015", time.Date(2015, time.June, 15, 0, 0, 0, 0, time.UTC)},
+		{German, "1.6.2015 um mitternacht", time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{Spanish, "el martes a mediodía", time.Date(2015, time.June, 2, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref), WithLanguage(tc.language))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Fatalf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if !tc.expected.Equal(res.Date) {
+			t.Errorf("Did not convert \"%s\". Expected: %s Actual: %s", tc.input, tc.expected, res.Date)
+		}
+	}
+}
+
+func TestLanguageIsolation(t *testing.T) {
+	// words of one language are not recognized by another
+	testCases := []struct {
+		language *Language
+		input    string
+	}{
+		{English, "mañana"},
+		{Spanish, "tomorrow"},
+		{German, "demain"},
+		{Spanish, "every tuesday"},
+		{Spanish, "noon"},
+		{French, "daily"},
+		{German, "eom"},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithLanguage(tc.language))
+		if _, err := c.Parse(newWordIterator(tc.input)); err == nil {
+			t.Errorf("Expected an error for \"%s\" in %s", tc.input, tc.language.Name)
+		}
+	}
+}
+
+func TestLanguageRangesAndRecurrences(t *testing.T) {
+	ref := sunday
+	day := func(month time.Month, monthday int) time.Time {
+		return time.Date(2015, month, monthday, 0, 0, 0, 0, time.UTC)
+	}
+
+	rangeCases := []struct {
+		language *Language
+		input    string
+		start    time.Time
+		end      time.Time
+	}{
+		{Spanish, "del 1 al 5 de junio", day(time.June, 1), day(time.June, 6).Add(-time.Nanosecond)},
+		{Spanish, "entre el lunes y el viernes", day(time.June, 1), day(time.June, 6).Add(-time.Nanosecond)},
+		{French, "du 1er au 5 juin", day(time.June, 1), day(time.June, 6).Add(-time.Nanosecond)},
+		{German, "vom 1. bis 5. juni", day(time.June, 1), day(time.June, 6).Add(-time.Nanosecond)},
+	}
+
+	for _, tc := range rangeCases {
+		c := NewClassifier(WithReference(ref), WithLanguage(tc.language))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil || res.Range == nil {
+			t.Errorf("\"%s\" was not parsed as a range: %v", tc.input, err)
+			continue
+		}
+
+		if r := res.Range; !r.Start.Equal(tc.start) || !r.End.Equal(tc.end) {
+			t.Errorf("Did not convert \"%s\". Expected: %s - %s Actual: %s - %s", tc.input, tc.start, tc.end, r.Start, r.End)
+		}
+	}
+
+	recurrenceCases := []struct {
+		language *Language
+		input    string
+		rule     string
+	}{
+		{Spanish, "todos los martes", "FREQ=WEEKLY;BYDAY=TU"},
+		{Spanish, "cada 2 semanas", "FREQ=WEEKLY;INTERVAL=2"},
+		{French, "tous les lundis", "FREQ=WEEKLY;BYDAY=MO"},
+		{German, "jeden freitag", "FREQ=WEEKLY;BYDAY=FR"},
+	}
+
+	for _, tc := range recurrenceCases {
+		c := NewClassifier(WithReference(ref), WithLanguage(tc.language))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil || res.Recurrence == nil {
+			t.Errorf("\"%s\" was not parsed as a recurrence: %v", tc.input, err)
+			continue
+		}
+
+		if rule := res.Recurrence.RRule(); rule != tc.rule {
+			t.Errorf("Did not convert \"%s\". Expected: %s Actual: %s", tc.input, tc.rule, rule)
+		}
+	}
+}
+
+func TestLookupLanguage(t *testing.T) {
+	for _, code := range []string{"en", "es", "fr", "de"} {
+		language, exists := LookupLanguage(code)
+		if !exists || language.Code != code {
+			t.Errorf("Expected a language pack for %s", code)
+		}
+	}
+
+	if _, exists := LookupLanguage("xx"); exists {
+		t.Errorf("Expected no language pack for xx")
+	}
+}
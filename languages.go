@@ -0,0 +1,555 @@
+package datelp
+
+// English is the default language of a Classifier
+var English = &Language{
+	Code: "en",
+	Name: "English",
+	Months: map[int][]string{
+		MONTH_JANUARY:   []string{"jan", "january"},
+		MONTH_FEBRUARY:  []string{"feb", "february"},
+		MONTH_MARCH:     []string{"mar", "march"},
+		MONTH_APRIL:     []string{"apr", "april"},
+		MONTH_MAY:       []string{"may"},
+		MONTH_JUNE:      []string{"jun", "june"},
+		MONTH_JULY:      []string{"jul", "july"},
+		MONTH_AUGUST:    []string{"aug", "august"},
+		MONTH_SEPTEMBER: []string{"sep", "sept", "september"},
+		MONTH_OCTOBER:   []string{"oct", "october"},
+		MONTH_NOVEMBER:  []string{"nov", "november"},
+		MONTH_DECEMBER:  []string{"dec", "december"},
+	},
+	Weekdays: map[int][]string{
+		WEEKDAY_SUNDAY:    []string{"s", "sunday", "sun", "sundays"},
+		WEEKDAY_MONDAY:    []string{"m", "monday", "mon", "mondays"},
+		WEEKDAY_TUESDAY:   []string{"t", "tuesday", "tues", "tuesdays"},
+		WEEKDAY_WEDNESDAY: []string{"t", "wednesday", "wed", "wednesdays"},
+		WEEKDAY_THURSDAY:  []string{"th", "thursday", "thurs", "thu", "thursdays"},
+		WEEKDAY_FRIDAY:    []string{"f", "friday", "fri", "fridays"},
+		WEEKDAY_SATURDAY:  []string{"s", "saturday", "sat", "saturdays"},
+	},
+	Directions: map[int][]string{
+		DIRECTION_CURRENT: []string{"this"},
+		DIRECTION_LEFT:    []string{"before", "ago", "last"},
+		DIRECTION_RIGHT:   []string{"next", "future", "from", "after"},
+	},
+	Synonyms: map[int][]string{
+		SYNONYM_YESTERDAY: []string{"yesterday"},
+		SYNONYM_TODAY:     []string{"today", "now"},
+		SYNONYM_TOMORROW:  []string{"tomorrow"},
+	},
+	Intervals: map[int][]string{
+		INTERVAL_DAY:          []string{"day", "days"},
+		INTERVAL_WEEK:         []string{"week", "weeks"},
+		INTERVAL_MONTH:        []string{"month", "months"},
+		INTERVAL_YEAR:         []string{"year", "years"},
+		INTERVAL_CENTURY:      []string{"century", "centuries"},
+		INTERVAL_FORTNIGHT:    []string{"fortnight", "fortnights"},
+		INTERVAL_HOUR:         []string{"hour", "hours", "hr", "hrs"},
+		INTERVAL_MINUTE:       []string{"minute", "minutes", "min", "mins"},
+		INTERVAL_SECOND:       []string{"second", "seconds", "sec", "secs", "s"},
+		INTERVAL_QUARTER:      []string{"quarter", "quarters"},
+		INTERVAL_BUSINESS_DAY: []string{"workday", "workdays"},
+	},
+	Ordinals: map[int][]string{
+		-1: []string{"last", "final"},
+		-2: []string{"second to last", "second-to-last", "penultimate"},
+	},
+	Boundaries: map[int][]string{
+		BOUNDARY_START:  []string{"start", "beginning"},
+		BOUNDARY_MIDDLE: []string{"middle", "mid"},
+		BOUNDARY_END:    []string{"end"},
+	},
+	Of:       []string{"of", "in"},
+	Business: []string{"business", "working", "work"},
+	Ending:   []string{"ending", "ended", "ends"},
+	Common:   []string{"and", "a", "an", "of", "the", "in", "at"},
+	Ahead:    []string{"in", "within"},
+	EndOf: map[int][]string{
+		INTERVAL_DAY:     []string{"eod"},
+		INTERVAL_WEEK:    []string{"eow"},
+		INTERVAL_MONTH:   []string{"eom"},
+		INTERVAL_QUARTER: []string{"eoq"},
+		INTERVAL_YEAR:    []string{"eoy"},
+	},
+	Times: map[int][]string{
+		0:         []string{"midnight"},
+		12 * 3600: []string{"noon", "midday"},
+	},
+	OClock:     []string{"o'clock", "oclock"},
+	Workweek:   []string{"weekday", "weekdays"},
+	Weekend:    []string{"weekend", "weekends"},
+	FiscalYear: []string{"fy"},
+	Fiscal:     []string{"fiscal"},
+	From:       []string{"from"},
+	Between:    []string{"between"},
+	To:         []string{"to", "through", "thru"},
+	Until:      []string{"until", "till", "til"},
+	And:        []string{"and"},
+	Or:         []string{"or"},
+	Every:      []string{"every", "each"},
+	Other:      []string{"other"},
+	On:         []string{"on"},
+	Frequencies: map[int][]string{
+		FREQUENCY_DAILY:   []string{"daily", "nightly"},
+		FREQUENCY_WEEKLY:  []string{"weekly"},
+		FREQUENCY_MONTHLY: []string{"monthly"},
+		FREQUENCY_YEARLY:  []string{"yearly", "annually"},
+	},
+	Starting: []string{"starting", "beginning"},
+	Except:   []string{"except", "excluding", "but"},
+	Order:    ORDER_MDY,
+	// TODO: parse words and figure out a way to look for word roots
+	// for instance eight + enth | y could be 18|80
+	Numbers: map[string]int{
+		"zero":      0,
+		"one":       1,
+		"two":       2,
+		"three":     3,
+		"four":      4,
+		"five":      5,
+		"six":       6,
+		"seven":     7,
+		"eight":     8,
+		"nine":      9,
+		"ten":       10,
+		"eleven":    11,
+		"twelve":    12,
+		"thirteen":  13,
+		"fourteen":  14,
+		"fifteen":   15,
+		"sixteen":   16,
+		"seventeen": 17,
+		"eighteen":  18,
+		"nineteen":  19,
+
+		"twenty":  20,
+		"thirty":  30,
+		"fourty":  40,
+		"fifty":   50,
+		"sixty":   60,
+		"seventy": 70,
+		"eighty":  80,
+		"ninety":  90,
+
+		"first":       1,
+		"second":      2,
+		"third":       3,
+		"fourth":      4,
+		"fifth":       5,
+		"sixth":       6,
+		"seventh":     7,
+		"eighth":      8,
+		"ninth":       9,
+		"tenth":       10,
+		"eleventh":    11,
+		"twelth":      12,
+		"thirteenth":  13,
+		"fourteenth":  14,
+		"fifteenth":   15,
+		"sixteenth":   16,
+		"seventeenth": 17,
+		"eighteenth":  18,
+		"nineteenth":  19,
+		"twentieth":   20,
+		"thirtieth":   30,
+
+		"hundred":  100,
+		"thousand": 1000,
+	},
+	Layouts: Layouts{
+		Future: "in %s",
+		Past:   "%s ago",
+		And:    "and",
+		Next:   map[int]string{INTERVAL_WEEKDAY: "next %s"},
+		Last:   map[int]string{INTERVAL_WEEKDAY: "last %s"},
+		This:   map[int]string{INTERVAL_WEEKDAY: "this %s"},
+		Date:   "%[2]s %[1]s",
+		Year:   "%s %d",
+		At:     "%s at %s",
+		Clock:  "3:04pm",
+		Hour:   "3pm",
+		Day:    englishOrdinal,
+	},
+}
+
+var Spanish = &Language{
+	Code: "es",
+	Name: "Spanish",
+	Months: map[int][]string{
+		MONTH_JANUARY:   []string{"ene", "enero"},
+		MONTH_FEBRUARY:  []string{"feb", "febrero"},
+		MONTH_MARCH:     []string{"marzo"},
+		MONTH_APRIL:     []string{"abr", "abril"},
+		MONTH_MAY:       []string{"mayo"},
+		MONTH_JUNE:      []string{"jun", "junio"},
+		MONTH_JULY:      []string{"jul", "julio"},
+		MONTH_AUGUST:    []string{"ago", "agosto"},
+		MONTH_SEPTEMBER: []string{"sep", "sept", "septiembre", "setiembre"},
+		MONTH_OCTOBER:   []string{"oct", "octubre"},
+		MONTH_NOVEMBER:  []string{"nov", "noviembre"},
+		MONTH_DECEMBER:  []string{"dic", "diciembre"},
+	},
+	Weekdays: map[int][]string{
+		WEEKDAY_SUNDAY:    []string{"dom", "domingo", "domingos"},
+		WEEKDAY_MONDAY:    []string{"lun", "lunes"},
+		WEEKDAY_TUESDAY:   []string{"martes"},
+		WEEKDAY_WEDNESDAY: []string{"mié", "mie", "miércoles", "miercoles"},
+		WEEKDAY_THURSDAY:  []string{"jue", "jueves"},
+		WEEKDAY_FRIDAY:    []string{"vie", "viernes"},
+		WEEKDAY_SATURDAY:  []string{"sáb", "sab", "sábado", "sabado", "sábados", "sabados"},
+	},
+	Directions: map[int][]string{
+		DIRECTION_CURRENT: []string{"este", "esta"},
+		DIRECTION_LEFT:    []string{"hace", "pasado", "pasada", "anterior", "antes"},
+		DIRECTION_RIGHT:   []string{"próximo", "próxima", "proximo", "proxima", "siguiente", "viene", "dentro", "después", "despues"},
+	},
+	Synonyms: map[int][]string{
+		SYNONYM_YESTERDAY: []string{"ayer"},
+		SYNONYM_TODAY:     []string{"hoy"},
+		SYNONYM_TOMORROW:  []string{"mañana", "manana"},
+	},
+	Intervals: map[int][]string{
+		INTERVAL_DAY:       []string{"día", "dia", "días", "dias"},
+		INTERVAL_WEEK:      []string{"semana", "semanas"},
+		INTERVAL_MONTH:     []string{"mes", "meses"},
+		INTERVAL_YEAR:      []string{"año", "años", "anos"},
+		INTERVAL_CENTURY:   []string{"siglo", "siglos"},
+		INTERVAL_FORTNIGHT: []string{"quincena", "quincenas"},
+		INTERVAL_HOUR:      []string{"hora", "horas"},
+		INTERVAL_MINUTE:    []string{"minuto", "minutos"},
+		INTERVAL_SECOND:    []string{"segundo", "segundos"},
+		INTERVAL_QUARTER:   []string{"trimestre", "trimestres"},
+	},
+	Ordinals: map[int][]string{
+		-1: []string{"último", "última", "ultimo", "ultima"},
+		-2: []string{"penúltimo", "penúltima", "penultimo", "penultima"},
+	},
+	Boundaries: map[int][]string{
+		BOUNDARY_START:  []string{"principio", "principios", "inicio", "comienzo"},
+		BOUNDARY_MIDDLE: []string{"mitad", "mediados"},
+		BOUNDARY_END:    []string{"fin", "final", "finales"},
+	},
+	Of:       []string{"de", "del"},
+	Business: []string{"hábil", "hábiles", "habil", "habiles", "laborable", "laborables"},
+	Common:   []string{"el", "la", "los", "las", "de", "del", "en", "a", "al", "y", "que"},
+	Ahead:    []string{"en"},
+	Times: map[int][]string{
+		0:         []string{"medianoche"},
+		12 * 3600: []string{"mediodía", "mediodia"},
+	},
+	Workweek:   []string{"entresemana"},
+	Weekend:    []string{"finde", "findes"},
+	FiscalYear: []string{"ejercicio"},
+	Fiscal:     []string{"fiscal"},
+	From:       []string{"desde", "del", "de"},
+	Between:    []string{"entre"},
+	To:         []string{"al", "hasta"},
+	And:        []string{"y"},
+	Or:         []string{"o"},
+	Every:      []string{"cada", "todos", "todas"},
+	Frequencies: map[int][]string{
+		FREQUENCY_DAILY:   []string{"diario", "diaria", "diariamente"},
+		FREQUENCY_WEEKLY:  []string{"semanal", "semanalmente"},
+		FREQUENCY_MONTHLY: []string{"mensual", "mensualmente"},
+		FREQUENCY_YEARLY:  []string{"anual", "anualmente"},
+	},
+	Except: []string{"excepto", "salvo", "menos"},
+	Order:  ORDER_DMY,
+	Layouts: Layouts{
+		Future: "dentro de %s",
+		Past:   "hace %s",
+		And:    "y",
+		Next:   map[int]string{INTERVAL_WEEKDAY: "%s próximo", INTERVAL_WEEK: "%s próxima"},
+		Last:   map[int]string{INTERVAL_WEEKDAY: "%s pasado", INTERVAL_WEEK: "%s pasada"},
+		This:   map[int]string{INTERVAL_WEEKDAY: "este %s", INTERVAL_WEEK: "esta %s"},
+		Date:   "%[1]s de %[2]s",
+		Year:   "%s de %d",
+		At:     "%s a las %s",
+		Clock:  "15:04",
+		Hour:   "15:04",
+	},
+	Numbers: map[string]int{
+		"cero":    0,
+		"un":      1,
+		"uno":     1,
+		"una":     1,
+		"dos":     2,
+		"tres":    3,
+		"cuatro":  4,
+		"cinco":   5,
+		"seis":    6,
+		"siete":   7,
+		"ocho":    8,
+		"nueve":   9,
+		"diez":    10,
+		"once":    11,
+		"doce":    12,
+		"trece":   13,
+		"catorce": 14,
+		"quince":  15,
+		"veinte":  20,
+		"treinta": 30,
+		"primero": 1,
+		"primer":  1,
+		"segundo": 2,
+		"tercero": 3,
+		"cuarto":  4,
+		"quinto":  5,
+		"cien":    100,
+		"mil":     1000,
+	},
+}
+
+var French = &Language{
+	Code: "fr",
+	Name: "French",
+	Months: map[int][]string{
+		MONTH_JANUARY:   []string{"janv", "janvier"},
+		MONTH_FEBRUARY:  []string{"févr", "fevr", "février", "fevrier"},
+		MONTH_MARCH:     []string{"mars"},
+		MONTH_APRIL:     []string{"avr", "avril"},
+		MONTH_MAY:       []string{"mai"},
+		MONTH_JUNE:      []string{"juin"},
+		MONTH_JULY:      []string{"juil", "juillet"},
+		MONTH_AUGUST:    []string{"août", "aout"},
+		MONTH_SEPTEMBER: []string{"septembre"},
+		MONTH_OCTOBER:   []string{"oct", "octobre"},
+		MONTH_NOVEMBER:  []string{"nov", "novembre"},
+		MONTH_DECEMBER:  []string{"déc", "dec", "décembre", "decembre"},
+	},
+	Weekdays: map[int][]string{
+		WEEKDAY_SUNDAY:    []string{"dim", "dimanche", "dimanches"},
+		WEEKDAY_MONDAY:    []string{"lun", "lundi", "lundis"},
+		WEEKDAY_TUESDAY:   []string{"mar", "mardi", "mardis"},
+		WEEKDAY_WEDNESDAY: []string{"mer", "mercredi", "mercredis"},
+		WEEKDAY_THURSDAY:  []string{"jeu", "jeudi", "jeudis"},
+		WEEKDAY_FRIDAY:    []string{"ven", "vendredi", "vendredis"},
+		WEEKDAY_SATURDAY:  []string{"sam", "samedi", "samedis"},
+	},
+	Directions: map[int][]string{
+		DIRECTION_CURRENT: []string{"ce", "cette"},
+		// "a" is the last word of "il y a"
+		DIRECTION_LEFT:  []string{"a", "dernier", "dernière", "derniere", "passé", "passée", "passe", "passee", "avant"},
+		DIRECTION_RIGHT: []string{"prochain", "prochaine", "dans", "après", "apres", "suivant", "suivante"},
+	},
+	Synonyms: map[int][]string{
+		SYNONYM_YESTERDAY: []string{"hier"},
+		SYNONYM_TODAY:     []string{"aujourd'hui", "aujourdhui"},
+		SYNONYM_TOMORROW:  []string{"demain"},
+	},
+	Intervals: map[int][]string{
+		INTERVAL_DAY:       []string{"jour", "jours"},
+		INTERVAL_WEEK:      []string{"semaine", "semaines"},
+		INTERVAL_MONTH:     []string{"mois"},
+		INTERVAL_YEAR:      []string{"an", "ans", "année", "années", "annee", "annees"},
+		INTERVAL_CENTURY:   []string{"siècle", "siècles", "siecle", "siecles"},
+		INTERVAL_FORTNIGHT: []string{"quinzaine", "quinzaines"},
+		INTERVAL_HOUR:      []string{"heure", "heures"},
+		INTERVAL_MINUTE:    []string{"minute", "minutes"},
+		INTERVAL_SECOND:    []string{"seconde", "secondes"},
+		INTERVAL_QUARTER:   []string{"trimestre", "trimestres"},
+	},
+	Ordinals: map[int][]string{
+		-1: []string{"dernier", "dernière", "derniere"},
+		-2: []string{"avant-dernier", "avant-dernière", "avant-derniere"},
+	},
+	Boundaries: map[int][]string{
+		BOUNDARY_START:  []string{"début", "debut", "commencement"},
+		BOUNDARY_MIDDLE: []string{"milieu", "mi"},
+		BOUNDARY_END:    []string{"fin"},
+	},
+	Of:       []string{"de", "du"},
+	Business: []string{"ouvré", "ouvrés", "ouvre", "ouvres", "ouvrable", "ouvrables"},
+	Common:   []string{"le", "la", "les", "de", "du", "des", "en", "et", "à", "au", "il", "y"},
+	Ahead:    []string{"en"},
+	Times: map[int][]string{
+		0:         []string{"minuit"},
+		12 * 3600: []string{"midi"},
+	},
+	OClock:     []string{"h"},
+	Weekend:    []string{"week-end", "week-ends", "weekend", "weekends"},
+	FiscalYear: []string{"exercice"},
+	Fiscal:     []string{"fiscal", "fiscale"},
+	From:       []string{"depuis", "du", "de"},
+	Between:    []string{"entre"},
+	To:         []string{"au", "jusqu'au", "jusqu'à"},
+	And:        []string{"et"},
+	Or:         []string{"ou"},
+	Every:      []string{"chaque", "tous", "toutes"},
+	Frequencies: map[int][]string{
+		FREQUENCY_DAILY:   []string{"quotidien", "quotidienne", "quotidiennement"},
+		FREQUENCY_WEEKLY:  []string{"hebdomadaire", "hebdomadairement"},
+		FREQUENCY_MONTHLY: []string{"mensuel", "mensuelle", "mensuellement"},
+		FREQUENCY_YEARLY:  []string{"annuel", "annuelle", "annuellement"},
+	},
+	Starting: []string{"dès"},
+	Except:   []string{"sauf", "excepté", "hormis"},
+	Order:    ORDER_DMY,
+	Layouts: Layouts{
+		Future: "dans %s",
+		Past:   "il y a %s",
+		And:    "et",
+		Next:   map[int]string{INTERVAL_WEEKDAY: "%s prochain", INTERVAL_WEEK: "%s prochaine", INTERVAL_YEAR: "%s prochaine"},
+		Last:   map[int]string{INTERVAL_WEEKDAY: "%s dernier", INTERVAL_WEEK: "%s dernière", INTERVAL_YEAR: "%s dernière"},
+		This:   map[int]string{INTERVAL_WEEKDAY: "ce %s", INTERVAL_WEEK: "cette %s", INTERVAL_YEAR: "cette %s"},
+		Date:   "%[1]s %[2]s",
+		Year:   "%s %d",
+		At:     "%s à %s",
+		Clock:  "15:04",
+		Hour:   "15:04",
+	},
+	Numbers: map[string]int{
+		"zéro":      0,
+		"un":        1,
+		"une":       1,
+		"deux":      2,
+		"trois":     3,
+		"quatre":    4,
+		"cinq":      5,
+		"six":       6,
+		"sept":      7,
+		"huit":      8,
+		"neuf":      9,
+		"dix":       10,
+		"onze":      11,
+		"douze":     12,
+		"treize":    13,
+		"quatorze":  14,
+		"quinze":    15,
+		"seize":     16,
+		"vingt":     20,
+		"trente":    30,
+		"premier":   1,
+		"première":  1,
+		"deuxième":  2,
+		"troisième": 3,
+		"cent":      100,
+		"mille":     1000,
+	},
+}
+
+var German = &Language{
+	Code: "de",
+	Name: "German",
+	Months: map[int][]string{
+		MONTH_JANUARY:   []string{"jan", "januar", "jänner"},
+		MONTH_FEBRUARY:  []string{"feb", "februar"},
+		MONTH_MARCH:     []string{"mär", "märz", "maerz"},
+		MONTH_APRIL:     []string{"apr", "april"},
+		MONTH_MAY:       []string{"mai"},
+		MONTH_JUNE:      []string{"jun", "juni"},
+		MONTH_JULY:      []string{"jul", "juli"},
+		MONTH_AUGUST:    []string{"aug", "august"},
+		MONTH_SEPTEMBER: []string{"sep", "sept", "september"},
+		MONTH_OCTOBER:   []string{"okt", "oktober"},
+		MONTH_NOVEMBER:  []string{"nov", "november"},
+		MONTH_DECEMBER:  []string{"dez", "dezember"},
+	},
+	Weekdays: map[int][]string{
+		WEEKDAY_SUNDAY:    []string{"sonntag", "sonntags"},
+		WEEKDAY_MONDAY:    []string{"montag", "montags"},
+		WEEKDAY_TUESDAY:   []string{"dienstag", "dienstags"},
+		WEEKDAY_WEDNESDAY: []string{"mittwoch", "mittwochs"},
+		WEEKDAY_THURSDAY:  []string{"donnerstag", "donnerstags"},
+		WEEKDAY_FRIDAY:    []string{"freitag", "freitags"},
+		WEEKDAY_SATURDAY:  []string{"samstag", "samstags", "sonnabend"},
+	},
+	Directions: map[int][]string{
+		DIRECTION_CURRENT: []string{"diese", "diesen", "dieser", "dieses"},
+		DIRECTION_LEFT:    []string{"vor", "letzte", "letzten", "letzter", "letztes", "vergangene", "vergangenen", "vorher"},
+		DIRECTION_RIGHT:   []string{"nach", "nächste", "nächsten", "nächster", "nächstes", "naechste", "naechsten", "kommende", "kommenden"},
+	},
+	Synonyms: map[int][]string{
+		SYNONYM_YESTERDAY: []string{"gestern"},
+		SYNONYM_TODAY:     []string{"heute"},
+		SYNONYM_TOMORROW:  []string{"morgen"},
+	},
+	Intervals: map[int][]string{
+		INTERVAL_DAY:          []string{"tag", "tage", "tagen"},
+		INTERVAL_WEEK:         []string{"woche", "wochen"},
+		INTERVAL_MONTH:        []string{"monat", "monate", "monaten"},
+		INTERVAL_YEAR:         []string{"jahr", "jahre", "jahren"},
+		INTERVAL_CENTURY:      []string{"jahrhundert", "jahrhunderte", "jahrhunderten"},
+		INTERVAL_HOUR:         []string{"stunde", "stunden"},
+		INTERVAL_MINUTE:       []string{"minute", "minuten"},
+		INTERVAL_SECOND:       []string{"sekunde", "sekunden"},
+		INTERVAL_QUARTER:      []string{"quartal", "quartale", "quartals"},
+		INTERVAL_BUSINESS_DAY: []string{"werktag", "werktage", "werktagen", "arbeitstag", "arbeitstage", "arbeitstagen"},
+	},
+	Ordinals: map[int][]string{
+		-1: []string{"letzte", "letzten", "letzter"},
+		-2: []string{"vorletzte", "vorletzten", "vorletzter"},
+	},
+	Boundaries: map[int][]string{
+		BOUNDARY_START:  []string{"anfang", "beginn"},
+		BOUNDARY_MIDDLE: []string{"mitte"},
+		BOUNDARY_END:    []string{"ende"},
+	},
+	Of:     []string{"im", "in", "des"},
+	Common: []string{"der", "die", "das", "den", "dem", "des", "am", "im", "um", "und", "in"},
+	Ahead:  []string{"in", "binnen"},
+	Times: map[int][]string{
+		0:         []string{"mitternacht"},
+		12 * 3600: []string{"mittag"},
+	},
+	OClock:     []string{"uhr"},
+	Weekend:    []string{"wochenende", "wochenenden"},
+	FiscalYear: []string{"gj", "geschäftsjahr", "geschaeftsjahr"},
+	From:       []string{"vom", "von", "ab"},
+	Between:    []string{"zwischen"},
+	To:         []string{"bis"},
+	And:        []string{"und"},
+	Or:         []string{"oder"},
+	Every:      []string{"jeden", "jede", "jedes", "alle"},
+	Frequencies: map[int][]string{
+		FREQUENCY_DAILY:   []string{"täglich", "taeglich"},
+		FREQUENCY_WEEKLY:  []string{"wöchentlich", "woechentlich"},
+		FREQUENCY_MONTHLY: []string{"monatlich"},
+		FREQUENCY_YEARLY:  []string{"jährlich", "jaehrlich"},
+	},
+	Except: []string{"außer", "ausser"},
+	Order:  ORDER_DMY,
+	Layouts: Layouts{
+		Future: "in %s",
+		Past:   "vor %s",
+		And:    "und",
+		Next:   map[int]string{INTERVAL_WEEKDAY: "nächsten %s", INTERVAL_WEEK: "nächste %s", INTERVAL_YEAR: "nächstes %s"},
+		Last:   map[int]string{INTERVAL_WEEKDAY: "letzten %s", INTERVAL_WEEK: "letzte %s", INTERVAL_YEAR: "letztes %s"},
+		This:   map[int]string{INTERVAL_WEEKDAY: "diesen %s", INTERVAL_WEEK: "diese %s", INTERVAL_YEAR: "dieses %s"},
+		Date:   "%[1]s. %[2]s",
+		Year:   "%s %d",
+		At:     "%s um %s",
+		Clock:  "15:04",
+		Hour:   "15:04",
+	},
+	Numbers: map[string]int{
+		"null":    0,
+		"ein":     1,
+		"eins":    1,
+		"eine":    1,
+		"einen":   1,
+		"einem":   1,
+		"zwei":    2,
+		"drei":    3,
+		"vier":    4,
+		"fünf":    5,
+		"sechs":   6,
+		"sieben":  7,
+		"acht":    8,
+		"neun":    9,
+		"zehn":    10,
+		"elf":     11,
+		"zwölf":   12,
+		"zwanzig": 20,
+		"dreißig": 30,
+		"erste":   1,
+		"ersten":  1,
+		"erster":  1,
+		"zweite":  2,
+		"zweiten": 2,
+		"dritte":  3,
+		"dritten": 3,
+		"hundert": 100,
+		"tausend": 1000,
+	},
+}
@@ -1,25 +1,24 @@
 package datelp
 
 import (
-	"errors"
 	"regexp"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 /*
    LeafClassifiers are responsible for classifying individual components of a
-   branch based upon known words and some fine-tuned classify logic
+   branch based upon known words and some fine-tuned classify logic. Those
+   which depend upon vocabulary are methods of a Language, and the package
+   level functions classify English.
 */
 func ClassifyWordAsCommon(word string) bool {
-	commonWords := []string{
-		"and",
-		"a",
-		"of",
-		"the",
-		"in",
-	}
+	return English.ClassifyWordAsCommon(word)
+}
 
-	for _, commonWord := range commonWords {
+func (l *Language) ClassifyWordAsCommon(word string) bool {
+	for _, commonWord := range l.Common {
 		if word == commonWord {
 			return true
 		}
@@ -29,87 +28,48 @@ func ClassifyWordAsCommon(word string) bool {
 }
 
 func ClassifyWordAsInteger(word string) (int, bool, error) {
+	return English.ClassifyWordAsInteger(word)
+}
+
+// the groups of digits of a word, eg: the 23 of 23rd
+var digits = regexp.MustCompile("-?[0-9]+")
+
+func (l *Language) ClassifyWordAsInteger(word string) (int, bool, error) {
 	// Classify a word as a potential integer. Its worth mentioning that if
 	// this word is a strictly mapped word, such as "twenty" it could be a
 	// part of stem. On the other hand, something like "23rd" isn't part of
 	// a word chain.
 
-	// TODO: parse words and figure out a way to look for word roots
-	// for instance eight + enth | y could be 18|80
-	mapping := map[string]int{
-		"zero":      0,
-		"one":       1,
-		"two":       2,
-		"three":     3,
-		"four":      4,
-		"five":      5,
-		"six":       6,
-		"seven":     7,
-		"eight":     8,
-		"nine":      9,
-		"ten":       10,
-		"eleven":    11,
-		"twelve":    12,
-		"thirteen":  13,
-		"fourteen":  14,
-		"fifteen":   15,
-		"sixteen":   16,
-		"seventeen": 17,
-		"eighteen":  18,
-		"nineteen":  19,
-
-		"twenty":  20,
-		"thirty":  30,
-		"fourty":  40,
-		"fifty":   50,
-		"sixty":   60,
-		"seventy": 70,
-		"eighty":  80,
-		"ninety":  90,
-
-		"first":       1,
-		"second":      2,
-		"third":       3,
-		"fourth":      4,
-		"fifth":       5,
-		"sixth":       6,
-		"seventh":     7,
-		"eighth":      8,
-		"ninth":       9,
-		"tenth":       10,
-		"eleventh":    11,
-		"twelth":      12,
-		"thirteenth":  13,
-		"fourteenth":  14,
-		"fifteenth":   15,
-		"sixteenth":   16,
-		"seventeenth": 17,
-		"eighteenth":  18,
-		"nineteenth":  19,
-		"twentieth":   20,
-		"thirtieth":   30,
-
-		"hundred":  100,
-		"thousand": 1000,
-	}
-
-	value, exists := mapping[word]
+	value, exists := l.Numbers[word]
 	if exists {
 		return value, true, nil
 	}
 
-	regex := regexp.MustCompile("-?[0-9]+")
-	number := regex.FindString(word)
-	integer, err := strconv.ParseInt(number, 10, 32)
+	// a word with several groups of digits, such as 06/01/2015, is a
+	// numeric date rather than an integer. Most words have none at all.
+	if !strings.ContainsAny(word, "0123456789") {
+		return 0, false, &ParseError{Err: ErrUnrecognized, Token: word, Index: -1, Expected: "integer"}
+	}
+
+	numbers := digits.FindAllString(word, -1)
+	if len(numbers) != 1 {
+		return 0, false, &ParseError{Err: ErrUnrecognized, Token: word, Index: -1, Expected: "integer"}
+	}
+
+	integer, err := strconv.ParseInt(numbers[0], 10, 32)
 
 	if err != nil {
-		return 0, false, errors.New("Not an integer")
+		return 0, false, &ParseError{Err: ErrUnrecognized, Token: word, Index: -1, Expected: "integer"}
 	}
 
 	return int(integer), false, nil
 }
 
 func ClassifyAsIntegerStem(i Iterator) (int, int, error) {
+	return English.ClassifyAsIntegerStem(i)
+}
+
+func (l *Language) ClassifyAsIntegerStem(i Iterator) (int, int, error) {
 	/*
 	   Classify a chain of integers and return a single integer. A stem is a
 	   series of related leaves that comprise a number. This attempts to
@@ -130,8 +90,8 @@ func ClassifyAsIntegerStem(i Iterator) (int, int, error) {
 			break
 		}
 
-		integer, stemPossible, err := ClassifyWordAsInteger(word)
-		isCommon := ClassifyWordAsCommon(word)
+		integer, stemPossible, err := l.ClassifyWordAsInteger(word)
+		isCommon := l.ClassifyWordAsCommon(word)
 
 		// if not an integer and not a common word we want to break
 		if err != nil && !isCommon {
@@ -152,7 +112,7 @@ func ClassifyAsIntegerStem(i Iterator) (int, int, error) {
 	}
 
 	if len(values) == 0 {
-		return 0, count, errors.New("No integer found")
+		return 0, count, newParseError(ErrUnrecognized, i, "integer")
 	}
 
 	if len(values) == 1 {
@@ -183,147 +143,700 @@ func ClassifyAsIntegerStem(i Iterator) (int, int, error) {
 }
 
 func ClassifyAsWeekday(i Iterator) (int, error) {
-	mappings := map[int][]string{
-		WEEKDAY_SUNDAY:    []string{"s", "sunday", "sun", "sundays"},
-		WEEKDAY_MONDAY:    []string{"m", "monday", "mon", "mondays"},
-		WEEKDAY_TUESDAY:   []string{"t", "tuesday", "tues", "tuesdays"},
-		WEEKDAY_WEDNESDAY: []string{"t", "wednesday", "wed", "wednesdays"},
-		WEEKDAY_THURSDAY:  []string{"th", "thursday", "thurs", "thu", "thursdays"},
-		WEEKDAY_FRIDAY:    []string{"f", "friday", "fri", "fridays"},
-		WEEKDAY_SATURDAY:  []string{"s", "saturday", "sat", "saturdays"},
-	}
-
-	for weekday, identifiers := range mappings {
-		for _, word := range identifiers {
-			if word == i.Current() {
-				return weekday, nil
-			}
+	return English.ClassifyAsWeekday(i)
+}
+
+func (l *Language) ClassifyAsWeekday(i Iterator) (int, error) {
+	weekdays, err := l.ClassifyAsWeekdayCandidates(i)
+	if err != nil {
+		return 0, err
+	}
+
+	return weekdays[0], nil
+}
+
+func ClassifyAsWeekdayCandidates(i Iterator) ([]int, error) {
+	return English.ClassifyAsWeekdayCandidates(i)
+}
+
+func (l *Language) ClassifyAsWeekdayCandidates(i Iterator) ([]int, error) {
+	// Classify a weekday and return every weekday that the word could
+	// refer to, in order of the week. Short forms such as `t` or `s` are
+	// shared by more than one weekday.
+	weekdays := l.lookup(l.Weekdays, weekdayOrder, i.Current())
+	if len(weekdays) == 0 {
+		return nil, newParseError(ErrUnrecognized, i, "weekday")
+	}
+
+	return weekdays, nil
+}
+
+func ClassifyAsPluralWeekday(i Iterator) (int, error) {
+	return English.ClassifyAsPluralWeekday(i)
+}
+
+func (l *Language) ClassifyAsPluralWeekday(i Iterator) (int, error) {
+	// Classify the plural form of a weekday, such as `tuesdays`, which
+	// refers to every one of that weekday rather than a single date
+	word := i.Current()
+	if !strings.HasSuffix(word, "s") {
+		return 0, newParseError(ErrUnrecognized, i, "plural weekday")
+	}
+
+	if _, err := l.ClassifyAsWeekday(newTokenIterator([]string{word[:len(word)-1]})); err != nil {
+		return 0, newParseError(ErrUnrecognized, i, "plural weekday")
+	}
+
+	return l.ClassifyAsWeekday(i)
+}
+
+func ClassifyAsDayGroup(i Iterator) ([]int, error) {
+	return English.ClassifyAsDayGroup(i)
+}
+
+func (l *Language) ClassifyAsDayGroup(i Iterator) ([]int, error) {
+	// Classify a word that refers to a group of weekdays at once
+	if contains(l.Workweek, i.Current()) {
+		return []int{WEEKDAY_MONDAY, WEEKDAY_TUESDAY, WEEKDAY_WEDNESDAY, WEEKDAY_THURSDAY, WEEKDAY_FRIDAY}, nil
+	}
+
+	if contains(l.Weekend, i.Current()) {
+		return []int{WEEKDAY_SATURDAY, WEEKDAY_SUNDAY}, nil
+	}
+
+	return nil, newParseError(ErrUnrecognized, i, "group of days")
+}
+
+func ClassifyAsTimeOfDay(i Iterator) (int, int, error) {
+	return English.ClassifyAsTimeOfDay(i)
+}
+
+// a time of day on a 12 or 24 hour clock, eg: 2:30pm
+var clockTime = regexp.MustCompile("^([0-9]{1,2})(:([0-9]{2}))?(:([0-9]{2}))?(am|pm|a\\.m\\.?|p\\.m\\.?)?$")
+
+func (l *Language) ClassifyAsTimeOfDay(i Iterator) (int, int, error) {
+	/*
+	   Classify a time of day and return the number of seconds past midnight
+	   that it refers to, along with the number of words used. Both 12 and
+	   24 hour clocks are supported, as well as a few named times:
+
+	           `3pm` `3 pm` `3 p.m.` `2:30am`
+	           `14:30` `14:30:15`
+	           `9 o'clock`
+	           `noon` `midnight`
+	*/
+	for seconds, names := range l.Times {
+		if contains(names, i.Current()) {
+			return seconds, 1, nil
 		}
 	}
 
-	return 0, errors.New("Not a weekday")
+	matches := clockTime.FindStringSubmatch(i.Current())
+	if matches == nil {
+		return 0, 0, newParseError(ErrUnrecognized, i, "time of day")
+	}
+
+	hour, _ := strconv.Atoi(matches[1])
+	minute, _ := strconv.Atoi(matches[3])
+	second, _ := strconv.Atoi(matches[5])
+	suffix := matches[6]
+	count := 1
+
+	// the meridiem or o'clock is commonly written as its own word
+	if next, err := i.Next(); err == nil && suffix == "" {
+		switch {
+		case next == "am", next == "pm", next == "a.m.", next == "p.m.", next == "a.m", next == "p.m":
+			suffix = next
+			count = 2
+		case contains(l.OClock, next):
+			suffix = "o'clock"
+			count = 2
+		}
+	}
+
+	// a lone number such as "3" is not a time of day
+	if suffix == "" && matches[2] == "" {
+		return 0, 0, newParseError(ErrUnrecognized, i, "time of day")
+	}
+
+	if minute > 59 || second > 59 {
+		return 0, 0, newParseError(ErrTimeOutOfRange, i, "time of day")
+	}
+
+	switch suffix {
+	case "am", "a.m.", "a.m":
+		if hour < 1 || hour > 12 {
+			return 0, 0, newParseError(ErrTimeOutOfRange, i, "time of day")
+		}
+		hour = hour % 12
+	case "pm", "p.m.", "p.m":
+		if hour < 1 || hour > 12 {
+			return 0, 0, newParseError(ErrTimeOutOfRange, i, "time of day")
+		}
+		hour = hour%12 + 12
+	default:
+		if hour > 23 {
+			return 0, 0, newParseError(ErrTimeOutOfRange, i, "time of day")
+		}
+	}
+
+	return hour*3600 + minute*60 + second, count, nil
 }
 
 func ClassifyAsDateday(i Iterator) (int, int, error) {
-	integer, count, err := ClassifyAsIntegerStem(i)
+	return English.ClassifyAsDateday(i)
+}
+
+func (l *Language) ClassifyAsDateday(i Iterator) (int, int, error) {
+	integer, count, err := l.ClassifyAsIntegerStem(i)
 	if err != nil {
 		return 0, 0, err
 	}
 
 	if integer > 31 || integer < 1 {
-		return 0, 0, errors.New("Integer out of daydate range")
+		return 0, 0, newParseError(ErrDayOutOfRange, i, "day of the month")
 	}
 
 	return integer, count, nil
 }
 
 func ClassifyAsMonth(i Iterator) (int, error) {
-	mappings := map[int][]string{
-		MONTH_JANUARY:   []string{"jan", "january"},
-		MONTH_FEBRUARY:  []string{"feb", "february"},
-		MONTH_MARCH:     []string{"mar", "march"},
-		MONTH_APRIL:     []string{"apr", "april"},
-		MONTH_MAY:       []string{"may"},
-		MONTH_JUNE:      []string{"june"},
-		MONTH_JULY:      []string{"july"},
-		MONTH_AUGUST:    []string{"aug", "august"},
-		MONTH_SEPTEMBER: []string{"sep", "sept", "september"},
-		MONTH_OCTOBER:   []string{"oct", "october"},
-		MONTH_NOVEMBER:  []string{"nov", "november"},
-		MONTH_DECEMBER:  []string{"dec", "december"},
-	}
-
-	for month, identifiers := range mappings {
-		for _, identifier := range identifiers {
-			if i.Current() == identifier {
-				return month, nil
-			}
-		}
+	return English.ClassifyAsMonth(i)
+}
+
+func (l *Language) ClassifyAsMonth(i Iterator) (int, error) {
+	if months := l.lookup(l.Months, monthOrder, i.Current()); len(months) > 0 {
+		return months[0], nil
 	}
 
-	integer, _, err := ClassifyWordAsInteger(i.Current())
+	integer, _, err := l.ClassifyWordAsInteger(i.Current())
 	if err != nil {
-		return 0, errors.New("Unable to parse as a month")
+		return 0, newParseError(ErrUnrecognized, i, "month")
+	}
+
+	if integer < 1 || integer > 12 {
+		return 0, newParseError(ErrInvalidMonth, i, "month")
+	}
+
+	return monthOrder[integer-1], nil
+}
+
+func ClassifyAsNumericDate(i Iterator, order int) (int, int, int, error) {
+	return English.ClassifyAsNumericDate(i, order)
+}
+
+// dates written as a single word, eg: 06/01/2015 or 01-jun-2015
+var (
+	numericDatePattern = regexp.MustCompile("^([0-9]{1,4})([-/.])([0-9]{1,2})(([-/.])([0-9]{1,4}))?$")
+	textualDate        = regexp.MustCompile("^([0-9]{1,2})[-/.]([^-/.0-9]+)[-/.]([0-9]{2}|[0-9]{4})$")
+)
+
+func (l *Language) ClassifyAsNumericDate(i Iterator, order int) (int, int, int, error) {
+	/*
+	   Classify a date written as a single word and return its year, month
+	   (as a constant) and day. The year is 0 when it is left out. A year
+	   written first is always followed by the month and dots always
+	   separate day.month.year, otherwise order decides which of the
+	   numbers is the month:
+
+	           `2015-06-01` `2015/06/01`
+	           `06/01/2015` `06/01/15` `06/01`
+	           `1.6.2015`
+	           `01-jun-2015`
+	*/
+	if matches := textualDate.FindStringSubmatch(i.Current()); matches != nil {
+		months := l.lookup(l.Months, monthOrder, matches[2])
+		if len(months) == 0 {
+			return 0, 0, 0, newParseError(ErrInvalidMonth, i, "numeric date")
+		}
+
+		month, _ := ConstantToMonth(months[0])
+		return numericDate(i, matches[3], strconv.Itoa(int(month)), matches[1])
+	}
+
+	matches := numericDatePattern.FindStringSubmatch(i.Current())
+	if matches == nil {
+		return 0, 0, 0, newParseError(ErrUnrecognized, i, "numeric date")
+	}
+
+	first, separator, second, third := matches[1], matches[2], matches[3], matches[6]
+
+	// both separators have to match, and a month and day on their own are
+	// only written with slashes, eg: 6/1
+	if (third != "" && matches[5] != separator) || (third == "" && separator != "/") {
+		return 0, 0, 0, newParseError(ErrUnrecognized, i, "numeric date")
+	}
+
+	switch {
+	case len(first) > 2:
+		if third == "" {
+			return 0, 0, 0, newParseError(ErrUnrecognized, i, "numeric date")
+		}
+		return numericDate(i, first, second, third)
+	case separator == "." || order == ORDER_DMY:
+		return numericDate(i, third, second, first)
+	case order == ORDER_YMD && third != "":
+		return numericDate(i, first, second, third)
+	}
+
+	return numericDate(i, third, first, second)
+}
+
+// numericDate validates the year, month and day of a numeric date as they
+// were written. Like the time package, two digit years fall between 1969 and
+// 2068.
+func numericDate(i Iterator, year, month, day string) (int, int, int, error) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+
+	switch len(year) {
+	case 0, 4:
+	case 2:
+		if y < 69 {
+			y += 2000
+		} else {
+			y += 1900
+		}
+	default:
+		return 0, 0, 0, newParseError(ErrUnrecognized, i, "numeric date")
 	}
 
-	if integer < 0 || integer > 12 {
-		return 0, errors.New("Unable to parse as a month")
+	if m < 1 || m > 12 {
+		return 0, 0, 0, newParseError(ErrInvalidMonth, i, "numeric date")
 	}
 
-	return integer, nil
+	if d < 1 || d > 31 {
+		return 0, 0, 0, newParseError(ErrDayOutOfRange, i, "numeric date")
+	}
+
+	return y, monthOrder[m-1], d, nil
 }
 
 func ClassifyAsYear(i Iterator) (int, int, error) {
-	year, count, err := ClassifyAsIntegerStem(i)
+	return English.ClassifyAsYear(i)
+}
+
+func (l *Language) ClassifyAsYear(i Iterator) (int, int, error) {
+	year, count, err := l.ClassifyAsIntegerStem(i)
 	if err != nil {
 		return 0, 0, err
 	}
 
 	if year < 1e3 {
-		return 0, 0, errors.New("Unable to classify as year")
+		return 0, 0, newParseError(ErrUnrecognized, i, "year")
 	}
 
 	return year, count, nil
 }
 
 func ClassifyAsCommon(i Iterator) (string, error) {
+	return English.ClassifyAsCommon(i)
+}
+
+func (l *Language) ClassifyAsCommon(i Iterator) (string, error) {
 	// for something like 3rd day of the month this would return for the "of the"
 	// 2 weeks in the future
-	if ClassifyWordAsCommon(i.Current()) {
+	if l.ClassifyWordAsCommon(i.Current()) {
 		return i.Current(), nil
 	}
 
-	return "", errors.New("Not a common word")
+	return "", newParseError(ErrUnrecognized, i, "common word")
 }
 
 func ClassifyAsDirection(i Iterator) (int, error) {
-	mapping := map[int][]string{
-		DIRECTION_CURRENT: []string{"this"},
-		DIRECTION_LEFT:    []string{"before", "ago", "last"},
-		DIRECTION_RIGHT:   []string{"next", "future", "from", "after"},
+	return English.ClassifyAsDirection(i)
+}
+
+func (l *Language) ClassifyAsDirection(i Iterator) (int, error) {
+	if directions := l.lookup(l.Directions, directionOrder, i.Current()); len(directions) > 0 {
+		return directions[0], nil
 	}
 
-	for interval, knownWords := range mapping {
-		for _, word := range knownWords {
-			if i.Current() == word {
-				return interval, nil
+	return 0, newParseError(ErrUnrecognized, i, "direction")
+}
+
+func ClassifyAsInterval(i Iterator) (int, error) {
+	return English.ClassifyAsInterval(i)
+}
+
+func (l *Language) ClassifyAsInterval(i Iterator) (int, error) {
+	intervals := l.lookup(l.Intervals, intervalOrder, i.Current())
+	if len(intervals) == 0 {
+		return 0, newParseError(ErrUnrecognized, i, "interval")
+	}
+
+	// a single letter such as the s of 30s is only an interval directly
+	// after a number, otherwise it could be anything
+	if utf8.RuneCountInString(i.Current()) == 1 {
+		prev, err := i.Prev()
+		if err != nil {
+			return 0, newParseError(ErrUnrecognized, i, "interval")
+		}
+
+		if _, _, err := l.ClassifyWordAsInteger(prev); err != nil {
+			return 0, newParseError(ErrUnrecognized, i, "interval")
+		}
+	}
+
+	return intervals[0], nil
+}
+
+func ClassifyAsBusinessDay(i Iterator) (int, error) {
+	return English.ClassifyAsBusinessDay(i)
+}
+
+func (l *Language) ClassifyAsBusinessDay(i Iterator) (int, error) {
+	// Classify business days written as a day along with a business word
+	// on either side of it, such as `business days` or `días hábiles`, and
+	// return the number of words used. Words such as `workdays` are plain
+	// intervals.
+	next, err := i.Next()
+	if err != nil {
+		return 0, newParseError(ErrUnrecognized, i, "business day")
+	}
+
+	day, business := next, i.Current()
+	if !contains(l.Business, business) {
+		day, business = i.Current(), next
+	}
+
+	if !contains(l.Business, business) {
+		return 0, newParseError(ErrUnrecognized, i, "business day")
+	}
+
+	if intervals := l.lookup(l.Intervals, intervalOrder, day); len(intervals) == 0 || intervals[0] != INTERVAL_DAY {
+		return 0, newParseError(ErrUnrecognized, i, "business day")
+	}
+
+	return 2, nil
+}
+
+func ClassifyAsAhead(i Iterator) (int, error) {
+	return English.ClassifyAsAhead(i)
+}
+
+func (l *Language) ClassifyAsAhead(i Iterator) (int, error) {
+	// Classify a word such as "in" which points to the future when it
+	// introduces a count of an interval, as in `in 3 hours` or `in a
+	// week`, but not when it introduces a date, as in `in june`
+	ahead := false
+	for _, word := range l.Ahead {
+		if i.Current() == word {
+			ahead = true
+		}
+	}
+
+	for n := 1; ahead; n++ {
+		word, err := i.NextNth(n)
+		if err != nil {
+			break
+		}
+
+		if _, _, err := l.ClassifyWordAsInteger(word); err == nil || l.ClassifyWordAsCommon(word) {
+			continue
+		}
+
+		if l.isInterval(word) {
+			return DIRECTION_RIGHT, nil
+		}
+		break
+	}
+
+	return 0, newParseError(ErrUnrecognized, i, "direction")
+}
+
+func ClassifyAsOrdinal(i Iterator) (int, int, error) {
+	return English.ClassifyAsOrdinal(i)
+}
+
+func (l *Language) ClassifyAsOrdinal(i Iterator) (int, int, error) {
+	/*
+	   Classify the position of a day inside of a period and return it
+	   along with the number of words used. Positions counted from the end
+	   of the period are negative:
+
+	           `first` `3rd` `fifth`
+	           `last` => -1
+	           `second to last` => -2
+	*/
+	for position := -1; position >= -len(l.Ordinals); position-- {
+		for _, ordinal := range l.Ordinals[position] {
+			words := strings.Fields(ordinal)
+			matched := true
+			for n, word := range words {
+				if next, err := i.NextNth(n); err != nil || next != word {
+					matched = false
+					break
+				}
+			}
+
+			if matched {
+				return position, len(words), nil
 			}
 		}
 	}
 
-	return 0, errors.New("Not a direction")
+	integer, _, err := l.ClassifyWordAsInteger(i.Current())
+	if err != nil || integer < 1 {
+		return 0, 0, newParseError(ErrUnrecognized, i, "ordinal")
+	}
+
+	return integer, 1, nil
 }
 
-func ClassifyAsInterval(i Iterator) (int, error) {
-	mapping := map[int]*regexp.Regexp{
-		INTERVAL_DAY:     regexp.MustCompile("^days?"),
-		INTERVAL_WEEK:    regexp.MustCompile("^week*"),
-		INTERVAL_MONTH:   regexp.MustCompile("^month*"),
-		INTERVAL_YEAR:    regexp.MustCompile("^year*"),
-		INTERVAL_CENTURY: regexp.MustCompile("^centur[y|ies]"),
+func ClassifyAsBoundary(i Iterator) (int, error) {
+	return English.ClassifyAsBoundary(i)
+}
+
+func (l *Language) ClassifyAsBoundary(i Iterator) (int, error) {
+	if boundaries := l.lookup(l.Boundaries, boundaryOrder, i.Current()); len(boundaries) > 0 {
+		return boundaries[0], nil
+	}
+
+	return 0, newParseError(ErrUnrecognized, i, "boundary")
+}
+
+func ClassifyAsBoundaryAbbreviation(i Iterator) (int, int, error) {
+	return English.ClassifyAsBoundaryAbbreviation(i)
+}
+
+func (l *Language) ClassifyAsBoundaryAbbreviation(i Iterator) (int, int, error) {
+	// Classify an abbreviation for the end of a period, such as `eom` for
+	// the end of the month, and return the boundary and the period
+	if periods := l.lookup(l.EndOf, intervalOrder, i.Current()); len(periods) > 0 {
+		return BOUNDARY_END, periods[0], nil
+	}
+
+	return 0, 0, newParseError(ErrUnrecognized, i, "boundary abbreviation")
+}
+
+// a quarter or half of the fiscal year, eg: q3
+var fiscalPeriod = regexp.MustCompile("^[qh][0-9]$")
+
+func ClassifyAsFiscalPeriod(i Iterator) (int, int, error) {
+	// Classify a quarter or half of the fiscal year, such as `q3` or `h1`,
+	// and return the period along with which one of them it is
+	if !fiscalPeriod.MatchString(i.Current()) {
+		return 0, 0, newParseError(ErrUnrecognized, i, "fiscal period")
+	}
+
+	period, count := INTERVAL_QUARTER, 4
+	if i.Current()[0] == 'h' {
+		period, count = INTERVAL_HALF, 2
 	}
 
-	for interval, re := range mapping {
-		if re.MatchString(i.Current()) {
-			return interval, nil
+	index := int(i.Current()[1] - '0')
+	if index < 1 || index > count {
+		return 0, 0, newParseError(ErrDayOutOfRange, i, "fiscal period")
+	}
+
+	return period, index, nil
+}
+
+func ClassifyAsFiscalYear(i Iterator) (int, int, error) {
+	return English.ClassifyAsFiscalYear(i)
+}
+
+func (l *Language) ClassifyAsFiscalYear(i Iterator) (int, int, error) {
+	/*
+	   Classify a fiscal year and return the year that it ends in, or 0
+	   when it is not written, along with the number of words used:
+
+	           `fy17` `fy 2017` `fiscal 2017` => 2017
+	           `fiscal year` `fy` => 0
+	*/
+	word := i.Current()
+	count := 1
+	number := ""
+	fiscal := false
+
+	switch {
+	case l.fiscalYear(word, &number):
+	case contains(l.Fiscal, word):
+		fiscal = true
+		if next, err := i.Next(); err == nil && contains(l.Intervals[INTERVAL_YEAR], next) {
+			count = 2
 		}
+	default:
+		return 0, 0, newParseError(ErrUnrecognized, i, "fiscal year")
+	}
+
+	if next, err := i.NextNth(count); err == nil && number == "" && fiscalYearNumber.MatchString(next) {
+		number = next
+		count += 1
 	}
 
-	return 0, errors.New("Not an interval")
+	// fiscal is only a fiscal year when followed by one, eg: fiscal policy
+	if fiscal && count == 1 {
+		return 0, 0, newParseError(ErrUnrecognized, i, "fiscal year")
+	}
+
+	value, _ := strconv.Atoi(number)
+	if len(number) == 2 {
+		// the same pivot as numeric dates, so that fy17 is 2017
+		if value < 69 {
+			value += 2000
+		} else {
+			value += 1900
+		}
+	}
+
+	return value, count, nil
 }
 
-func ClassifyAsDaySynonym(i Iterator) (int, error) {
-	mapping := map[int]string{
-		SYNONYM_YESTERDAY: "yesterday",
-		SYNONYM_TODAY:     "today",
-		SYNONYM_TOMORROW:  "tomorrow",
+// the number of a fiscal year, eg: the 17 of fy17
+var fiscalYearNumber = regexp.MustCompile("^([0-9]{2}|[0-9]{4})$")
+
+// fiscalYear returns whether the word is one of the FiscalYear words, with or
+// without the number of the year glued to it, and sets number to that number
+func (l *Language) fiscalYear(word string, number *string) bool {
+	for _, prefix := range l.FiscalYear {
+		if !strings.HasPrefix(word, prefix) {
+			continue
+		}
+
+		if rest := word[len(prefix):]; rest == "" || fiscalYearNumber.MatchString(rest) {
+			*number = rest
+			return true
+		}
 	}
 
-	for synonym, keyword := range mapping {
-		if i.Current() == keyword {
-			return synonym, nil
+	return false
+}
+
+// an ISO 8601 week date, eg: 2016-w23-2
+var isoWeekPattern = regexp.MustCompile("^([0-9]{4})-?w([0-9]{2})(-?([0-9]))?$")
+
+func ClassifyAsISOWeek(i Iterator) (int, int, int, error) {
+	/*
+	   Classify an ISO 8601 week date and return its year, week and the
+	   day of the week from 1 for monday to 7 for sunday. The day is 0
+	   when it is not written:
+
+	           `2016-w23` `2016w23` => 2016, 23, 0
+	           `2016-w23-2` => 2016, 23, 2
+	*/
+	matches := isoWeekPattern.FindStringSubmatch(i.Current())
+	if matches == nil {
+		return 0, 0, 0, newParseError(ErrUnrecognized, i, "iso week")
+	}
+
+	year, _ := strconv.Atoi(matches[1])
+	week, _ := strconv.Atoi(matches[2])
+	day, _ := strconv.Atoi(matches[4])
+
+	if week < 1 || week > 53 || (matches[4] != "" && (day < 1 || day > 7)) {
+		return 0, 0, 0, newParseError(ErrDayOutOfRange, i, "iso week")
+	}
+
+	return year, week, day, nil
+}
+
+func ClassifyAsWeekNumber(i Iterator) (int, int, error) {
+	return English.ClassifyAsWeekNumber(i)
+}
+
+// the number of a week of the year, eg: w23
+var weekNumber = regexp.MustCompile("^w?([0-9]{1,2})$")
+
+func (l *Language) ClassifyAsWeekNumber(i Iterator) (int, int, error) {
+	// Classify the number of a week of the year, such as `week 23` or
+	// `w23`, and return it along with the number of words used
+	count := 1
+
+	if interval, err := l.ClassifyAsInterval(i); err == nil && interval == INTERVAL_WEEK {
+		count = 2
+	} else if !strings.HasPrefix(i.Current(), "w") {
+		return 0, 0, newParseError(ErrUnrecognized, i, "week number")
+	}
+
+	word, err := i.NextNth(count - 1)
+	if err != nil {
+		return 0, 0, newParseError(ErrUnrecognized, i, "week number")
+	}
+
+	// the number of week 23 is written on its own, unlike that of w23
+	matches := weekNumber.FindStringSubmatch(word)
+	if matches == nil || (count == 2) == strings.HasPrefix(word, "w") {
+		return 0, 0, newParseError(ErrUnrecognized, i, "week number")
+	}
+
+	week, _ := strconv.Atoi(matches[1])
+	if week < 1 || week > 53 {
+		return 0, 0, &ParseError{
+			Err:      ErrDayOutOfRange,
+			Token:    word,
+			Index:    i.Index() + count - 1,
+			Expected: "week number",
 		}
 	}
 
-	return 0, errors.New("Not a day synonym")
+	return week, count, nil
+}
+
+func ClassifyAsWeekOf(i Iterator) (bool, int, error) {
+	return English.ClassifyAsWeekOf(i)
+}
+
+func (l *Language) ClassifyAsWeekOf(i Iterator) (bool, int, error) {
+	// Classify a week named by one of its days and return whether the
+	// week ends on that day, along with the number of words used:
+	// `the week of june 1st` or `week ending friday`
+	if interval, err := l.ClassifyAsInterval(i); err != nil || interval != INTERVAL_WEEK {
+		return false, 0, newParseError(ErrUnrecognized, i, "week of")
+	}
+
+	next, err := i.Next()
+	switch {
+	case err != nil:
+	case contains(l.Of, next):
+		return false, 2, nil
+	case contains(l.Ending, next):
+		return true, 2, nil
+	}
+
+	return false, 0, newParseError(ErrUnrecognized, i, "week of")
+}
+
+func ClassifyAsDaySynonym(i Iterator) (int, error) {
+	return English.ClassifyAsDaySynonym(i)
+}
+
+func (l *Language) ClassifyAsDaySynonym(i Iterator) (int, error) {
+	if synonyms := l.lookup(l.Synonyms, synonymOrder, i.Current()); len(synonyms) > 0 {
+		return synonyms[0], nil
+	}
+
+	return 0, newParseError(ErrUnrecognized, i, "day synonym")
+}
+
+func ClassifyAsFrequency(i Iterator) (int, error) {
+	return English.ClassifyAsFrequency(i)
+}
+
+func (l *Language) ClassifyAsFrequency(i Iterator) (int, error) {
+	if frequencies := l.lookup(l.Frequencies, frequencyOrder, i.Current()); len(frequencies) > 0 {
+		return frequencies[0], nil
+	}
+
+	return 0, newParseError(ErrUnrecognized, i, "frequency")
+}
+
+func ClassifyAsRangeSeparator(i Iterator) (bool, error) {
+	return English.ClassifyAsRangeSeparator(i)
+}
+
+func (l *Language) ClassifyAsRangeSeparator(i Iterator) (bool, error) {
+	// Classify a word that separates the two ends of a range, such as
+	// `june 1st through june 5th`, and return whether the end of the range
+	// is included in the range. A dash separates a range in any language.
+	switch word := i.Current(); {
+	case word == "-", contains(l.To, word), contains(l.And, word):
+		return true, nil
+	case contains(l.Until, word):
+		return false, nil
+	}
+
+	return false, newParseError(ErrUnrecognized, i, "range separator")
 }
@@ -1,6 +1,7 @@
 package datelp
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -27,4 +28,327 @@ func TestClassifyAsIntegerBranch(t *testing.T) {
 	}
 }
 
+func TestClassifyAsTimeOfDay(t *testing.T) {
+	testCases := []struct {
+		input   string
+		seconds int
+		count   int
+		valid   bool
+	}{
+		{"3pm", 15 * 3600, 1, true},
+		{"3 pm", 15 * 3600, 2, true},
+		{"3 p.m.", 15 * 3600, 2, true},
+		{"12am", 0, 1, true},
+		{"12 pm", 12 * 3600, 2, true},
+		{"2:30am", 2*3600 + 30*60, 1, true},
+		{"14:30", 14*3600 + 30*60, 1, true},
+		{"14:30:15", 14*3600 + 30*60 + 15, 1, true},
+		{"9 o'clock", 9 * 3600, 2, true},
+		{"noon", 12 * 3600, 1, true},
+		{"midnight", 0, 1, true},
+		{"3", 0, 0, false},
+		{"0am", 0, 0, false},
+		{"0 pm", 0, 0, false},
+		{"13pm", 0, 0, false},
+		{"25:00", 0, 0, false},
+		{"10:75", 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		seconds, count, err := ClassifyAsTimeOfDay(newWordIterator(tc.input))
+		if (err == nil) != tc.valid {
+			t.Errorf("\"%s\" classified wrong. expected valid: %t error: %v", tc.input, tc.valid, err)
+			continue
+		}
+
+		if seconds != tc.seconds || count != tc.count {
+			t.Errorf("\"%s\" classified wrong. expected: %d (%d words) actual: %d (%d words)",
+				tc.input, tc.seconds, tc.count, seconds, count)
+		}
+	}
+}
+
+func TestClassifyAsNumericDate(t *testing.T) {
+	testCases := []struct {
+		input string
+		order int
+		year  int
+		month int
+		day   int
+		valid bool
+	}{
+		{"2015-06-01", ORDER_MDY, 2015, MONTH_JUNE, 1, true},
+		{"2015/06/01", ORDER_DMY, 2015, MONTH_JUNE, 1, true},
+		{"06/01/2015", ORDER_MDY, 2015, MONTH_JUNE, 1, true},
+		{"06/01/2015", ORDER_DMY, 2015, MONTH_JANUARY, 6, true},
+		{"15/06/01", ORDER_YMD, 2015, MONTH_JUNE, 1, true},
+		{"06/01/15", ORDER_MDY, 2015, MONTH_JUNE, 1, true},
+		{"06/01/99", ORDER_MDY, 1999, MONTH_JUNE, 1, true},
+		{"6/1", ORDER_MDY, 0, MONTH_JUNE, 1, true},
+		{"1.6.2015", ORDER_MDY, 2015, MONTH_JUNE, 1, true},
+		{"01-jun-2015", ORDER_MDY, 2015, MONTH_JUNE, 1, true},
+		{"13/01/2015", ORDER_MDY, 0, 0, 0, false},
+		{"06/32/2015", ORDER_MDY, 0, 0, 0, false},
+		{"06/01-2015", ORDER_MDY, 0, 0, 0, false},
+		{"01-foo-2015", ORDER_MDY, 0, 0, 0, false},
+		{"1-5", ORDER_MDY, 0, 0, 0, false},
+		{"3.5", ORDER_MDY, 0, 0, 0, false},
+		{"2015", ORDER_MDY, 0, 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		year, month, day, err := ClassifyAsNumericDate(newWordIterator(tc.input), tc.order)
+		if (err == nil) != tc.valid {
+			t.Errorf("\"%s\" classified wrong. expected valid: %t error: %v", tc.input, tc.valid, err)
+			continue
+		}
+
+		if year != tc.year || month != tc.month || day != tc.day {
+			t.Errorf("\"%s\" classified wrong. expected: %d %d %d actual: %d %d %d",
+				tc.input, tc.year, tc.month, tc.day, year, month, day)
+		}
+	}
+}
+
+func TestClassifyAsInterval(t *testing.T) {
+	testCases := []struct {
+		input    string
+		interval int
+		valid    bool
+	}{
+		{"3 hrs", INTERVAL_HOUR, true},
+		{"3 mins", INTERVAL_MINUTE, true},
+		{"3 sec", INTERVAL_SECOND, true},
+		{"30 s", INTERVAL_SECOND, true},
+		{"on s", 0, false},
+		{"2 fortnights", INTERVAL_FORTNIGHT, true},
+		{"2 weekdays", 0, false},
+	}
+
+	for _, tc := range testCases {
+		i := newWordIterator(tc.input)
+		i.Move()
+
+		interval, err := ClassifyAsInterval(i)
+		if (err == nil) != tc.valid || interval != tc.interval {
+			t.Errorf("\"%s\" classified wrong. expected: %d (valid: %t) actual: %d (%v)", tc.input, tc.interval, tc.valid, interval, err)
+		}
+	}
+}
+
+func TestClassifyAsAhead(t *testing.T) {
+	inputs := map[string]bool{
+		"in 3 hours":      true,
+		"in a week":       true,
+		"within two days": true,
+		"in june":         false,
+		"in 2015":         false,
+		"next week":       false,
+	}
+
+	for input, valid := range inputs {
+		direction, err := ClassifyAsAhead(newWordIterator(input))
+		if (err == nil) != valid || (valid && direction != DIRECTION_RIGHT) {
+			t.Errorf("\"%s\" classified wrong. expected valid: %t error: %v", input, valid, err)
+		}
+	}
+}
+
+func TestClassifyAsOrdinal(t *testing.T) {
+	testCases := []struct {
+		input    string
+		position int
+		size     int
+	}{
+		{"first monday", 1, 1},
+		{"3rd wednesday", 3, 1},
+		{"fifth day", 5, 1},
+		{"last friday", -1, 1},
+		{"second to last day", -2, 3},
+		{"penultimate week", -2, 1},
+	}
+
+	for _, tc := range testCases {
+		position, size, err := ClassifyAsOrdinal(newWordIterator(tc.input))
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+		}
+
+		if position != tc.position || size != tc.size {
+			t.Errorf("\"%s\" classified wrong. expected: %d/%d actual: %d/%d", tc.input, tc.position, tc.size, position, size)
+		}
+	}
+}
+
+func TestClassifyAsBoundaryAbbreviation(t *testing.T) {
+	inputs := map[string]int{
+		"eod": INTERVAL_DAY,
+		"eow": INTERVAL_WEEK,
+		"eom": INTERVAL_MONTH,
+		"eoq": INTERVAL_QUARTER,
+		"eoy": INTERVAL_YEAR,
+	}
+
+	for input, expected := range inputs {
+		boundary, period, err := ClassifyAsBoundaryAbbreviation(newWordIterator(input))
+		if err != nil || boundary != BOUNDARY_END || period != expected {
+			t.Errorf("\"%s\" classified wrong. expected period: %d actual: %d error: %v", input, expected, period, err)
+		}
+	}
+
+	if _, _, err := ClassifyAsBoundaryAbbreviation(newWordIterator("end")); err == nil {
+		t.Errorf("Expected an error for \"end\"")
+	}
+}
+
+func TestClassifyAsFiscalPeriod(t *testing.T) {
+	testCases := []struct {
+		input  string
+		period int
+		index  int
+		err    error
+	}{
+		{"q3", INTERVAL_QUARTER, 3, nil},
+		{"h2", INTERVAL_HALF, 2, nil},
+		{"q5", 0, 0, ErrDayOutOfRange},
+		{"q0", 0, 0, ErrDayOutOfRange},
+		{"h3", 0, 0, ErrDayOutOfRange},
+		{"q", 0, 0, ErrUnrecognized},
+		{"q10", 0, 0, ErrUnrecognized},
+	}
+
+	for _, tc := range testCases {
+		period, index, err := ClassifyAsFiscalPeriod(newWordIterator(tc.input))
+		if !errors.Is(err, tc.err) || period != tc.period || index != tc.index {
+			t.Errorf("\"%s\" classified wrong. expected: %d/%d (%v) actual: %d/%d (%v)", tc.input, tc.period, tc.index, tc.err, period, index, err)
+		}
+	}
+}
+
+func TestClassifyAsFiscalYear(t *testing.T) {
+	testCases := []struct {
+		input string
+		year  int
+		count int
+		valid bool
+	}{
+		{"fy17", 2017, 2, true},
+		{"fy 2017", 2017, 2, true},
+		{"fiscal year 2016", 2016, 3, true},
+		{"fiscal 2016", 2016, 2, true},
+		{"fiscal year", 0, 2, true},
+		{"fy", 0, 1, true},
+		{"fiscal policy", 0, 0, false},
+		{"year", 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		year, count, err := ClassifyAsFiscalYear(newWordIterator(tc.input))
+		if (err == nil) != tc.valid || year != tc.year || count != tc.count {
+			t.Errorf("\"%s\" classified wrong. expected: %d/%d actual: %d/%d error: %v", tc.input, tc.year, tc.count, year, count, err)
+		}
+	}
+}
+
+func TestClassifyAsISOWeek(t *testing.T) {
+	testCases := []struct {
+		input string
+		date  [3]int
+		valid bool
+	}{
+		{"2016-w23", [3]int{2016, 23, 0}, true},
+		{"2016w23", [3]int{2016, 23, 0}, true},
+		{"2016-w23-2", [3]int{2016, 23, 2}, true},
+		{"2016-w60", [3]int{}, false},
+		{"2016-w23-8", [3]int{}, false},
+		{"2016-w23-0", [3]int{}, false},
+		{"2016-06-01", [3]int{}, false},
+	}
+
+	for _, tc := range testCases {
+		year, week, day, err := ClassifyAsISOWeek(newWordIterator(tc.input))
+		if (err == nil) != tc.valid || [3]int{year, week, day} != tc.date {
+			t.Errorf("\"%s\" classified wrong. expected: %v actual: %v error: %v", tc.input, tc.date, [3]int{year, week, day}, err)
+		}
+	}
+}
+
+func TestClassifyAsWeekNumber(t *testing.T) {
+	testCases := []struct {
+		input string
+		week  int
+		count int
+		valid bool
+	}{
+		{"week 23", 23, 2, true},
+		{"w23", 23, 1, true},
+		{"week w23", 0, 0, false},
+		{"week 54", 0, 0, false},
+		{"weeks ago", 0, 0, false},
+		{"wednesday", 0, 0, false},
+		{"next week 3pm", 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		week, count, err := ClassifyAsWeekNumber(newWordIterator(tc.input))
+		if (err == nil) != tc.valid || week != tc.week || count != tc.count {
+			t.Errorf("\"%s\" classified wrong. expected: %d/%d actual: %d/%d error: %v", tc.input, tc.week, tc.count, week, count, err)
+		}
+	}
+}
+
+func TestClassifyAsBusinessDay(t *testing.T) {
+	testCases := []struct {
+		language *Language
+		input    string
+		valid    bool
+	}{
+		{English, "business days", true},
+		{English, "working day", true},
+		{English, "business weeks", false},
+		{English, "days ago", false},
+		{Spanish, "días hábiles", true},
+		{French, "jours ouvrés", true},
+	}
+
+	for _, tc := range testCases {
+		count, err := tc.language.ClassifyAsBusinessDay(newWordIterator(tc.input))
+		if (err == nil) != tc.valid || (tc.valid && count != 2) {
+			t.Errorf("\"%s\" classified wrong. expected valid: %t count: %d error: %v", tc.input, tc.valid, count, err)
+		}
+	}
+}
+
+func TestClassifyAsPluralWeekday(t *testing.T) {
+	inputs := map[string]bool{
+		"tuesdays": true,
+		"sundays":  true,
+		"tuesday":  false,
+		"tues":     false,
+		"thurs":    false,
+		"s":        false,
+	}
+
+	for input, expected := range inputs {
+		_, err := ClassifyAsPluralWeekday(newWordIterator(input))
+		if (err == nil) != expected {
+			t.Errorf("\"%s\" classified wrong as a plural weekday. expected: %t", input, expected)
+		}
+	}
+}
+
+func TestClassifyAsWeekdayCandidates(t *testing.T) {
+	// shared short forms resolve in order of the week, never by chance
+	for n := 0; n < 20; n++ {
+		if value, _ := ClassifyAsWeekday(newWordIterator("s")); value != WEEKDAY_SUNDAY {
+			t.Fatalf("\"s\" should always resolve to sunday first")
+		}
+	}
+
+	values, err := ClassifyAsWeekdayCandidates(newWordIterator("t"))
+	if err != nil || len(values) != 2 || values[0] != WEEKDAY_TUESDAY || values[1] != WEEKDAY_WEDNESDAY {
+		t.Errorf("\"t\" returned the wrong candidates: %v", values)
+	}
+}
+
 // TODO: write tests for other classifiers
@@ -0,0 +1,258 @@
+package datelp
+
+import (
+	"regexp"
+	"time"
+)
+
+// the maximum number of words that a range expression is searched across
+const rangeWindow = 16
+
+// days of the month written as a range in a single word, eg: 1-5
+var dayRange = regexp.MustCompile("^([0-9]+)-([0-9]+)$")
+
+// Range is a span of time between two dates, such as "june 1st to june 5th"
+type Range struct {
+	Start     time.Time
+	End       time.Time
+	Inclusive bool // whether End itself belongs to the range
+}
+
+func (r Range) Contains(t time.Time) bool {
+	if t.Before(r.Start) {
+		return false
+	}
+
+	if r.Inclusive {
+		return !t.After(r.End)
+	}
+
+	return t.Before(r.End)
+}
+
+func (c *Classifier) parseRange(i Iterator) (*Result, int, error) {
+	/*
+	   Parse a range starting at the current position of the iterator
+	   without moving it. Returns the range as a result precise to the
+	   finest of its ends, and the number of words of the iterator that
+	   it spans. Both ends of the range are classified in isolation, but
+	   share any components that are only written once:
+
+	           from june 1st to june 5th
+	           between monday and friday
+	           june 30 - july 2
+	           june 1-5 2015
+	           del 1 al 5 de junio
+	*/
+	words := make([]string, 0)
+	origins := make([]int, 0)
+
+	for n := 0; n < rangeWindow; n++ {
+		word, err := i.NextNth(n)
+		if err != nil {
+			break
+		}
+
+		// a day range such as 1-5 is split into its own words while
+		// remembering which word of the iterator each came from
+		if parts := dayRange.FindStringSubmatch(word); parts != nil {
+			words = append(words, parts[1], "-", parts[2])
+			origins = append(origins, n, n, n)
+			continue
+		}
+
+		words = append(words, word)
+		origins = append(origins, n)
+	}
+
+	start := 0
+	between := false
+	if len(words) > 0 && (contains(c.lang().From, words[0]) || contains(c.lang().Between, words[0])) {
+		start = 1
+		between = contains(c.lang().Between, words[0])
+	}
+
+	// the month words are looked up once rather than for each separator
+	months := make([]string, len(words))
+	for index := range words {
+		months[index] = c.monthWord(words[index : index+1])
+	}
+	firstMonth := func(from, to int) string {
+		for index := from; index < to; index++ {
+			if months[index] != "" {
+				return months[index]
+			}
+		}
+
+		return ""
+	}
+
+	var backwards *ParseError
+	iterator := newTokenIterator(words)
+	for separator := start + 1; separator < len(words)-1; separator++ {
+		iterator.MoveN(separator - iterator.Index())
+		inclusive, err := c.lang().ClassifyAsRangeSeparator(iterator)
+		if err != nil || contains(c.lang().And, words[separator]) != between {
+			continue
+		}
+
+		left := words[start:separator]
+		right := words[separator+1:]
+
+		// the month is often only written once, eg: june 1-5
+		shared := 0
+		if month := firstMonth(start, separator); month != "" && months[separator+1] == "" {
+			if _, _, err := c.lang().ClassifyWordAsInteger(right[0]); err == nil {
+				right = append([]string{month}, right...)
+				shared = 1
+			}
+		} else if month := firstMonth(separator+1, len(words)); month != "" && firstMonth(start, separator) == "" {
+			// or only at the end, eg: del 1 al 5 de junio
+			if _, _, err := c.lang().ClassifyWordAsInteger(left[len(left)-1]); err == nil {
+				left = append(append([]string{}, left...), month)
+			}
+		}
+
+		from := c.fork()
+		if err := from.buildContexts(newTokenIterator(left)); err != nil || !from.anchored || from.last != len(left)-1 {
+			continue
+		}
+
+		to := c.fork()
+		if err := to.buildContexts(newTokenIterator(right)); err != nil || !to.anchored || to.last < shared {
+			continue
+		}
+
+		// as is the year, eg: june 30 - july 2 2015
+		startShared := from.date.year == 0 && to.date.year != 0
+		endShared := to.date.year == 0 && from.date.year != 0
+		if startShared {
+			from.date.year = to.date.year
+		}
+		if endShared {
+			to.date.year = from.date.year
+		}
+
+		startResult, err := from.compile()
+		if err != nil {
+			continue
+		}
+
+		// and the day, when the end of the range is only a time, eg:
+		// tomorrow 3pm to 5pm
+		if to.date.size == 0 && to.offset.size == 0 {
+			to.start = startResult.Date
+		}
+
+		endResult, err := to.compile()
+		if err != nil {
+			continue
+		}
+
+		// a range which ends in an earlier month than it starts crosses
+		// into the next year, eg: dec 30 - jan 2, unless both of its
+		// years were written
+		if endResult.Date.Before(startResult.Date) && endResult.Date.Month() < startResult.Date.Month() {
+			switch {
+			case startShared:
+				shiftYears(startResult, -1)
+			case endShared || !endResult.Explicit.Year:
+				shiftYears(endResult, 1)
+			}
+		}
+
+		if endResult.Date.Before(startResult.Date) {
+			if backwards == nil {
+				backwards = &ParseError{
+					Err:      ErrInvalidRange,
+					Token:    words[separator+1],
+					Index:    i.Index() + origins[separator+1],
+					Expected: "range",
+				}
+			}
+			continue
+		}
+
+		last := separator + to.last + 1 - shared
+		r := &Range{
+			Start:     rangeStart(startResult),
+			End:       rangeEnd(endResult, inclusive),
+			Inclusive: inclusive,
+		}
+
+		return &Result{
+			Date:      r.Start,
+			Kind:      KIND_RANGE,
+			Range:     r,
+			Precision: finer(startResult.Precision, endResult.Precision),
+			Explicit:  startResult.Explicit,
+			Period:    r,
+		}, origins[last] + 1, nil
+	}
+
+	if backwards != nil {
+		return nil, 0, backwards
+	}
+
+	return nil, 0, newParseError(ErrUnrecognized, i, "range")
+}
+
+// rangeStart returns the start of a range which starts at result. A day or
+// any longer period starts at its first instant, while a time of day such as
+// 3pm is an instant of its own.
+func rangeStart(result *Result) time.Time {
+	if finer(result.Precision, INTERVAL_DAY) != INTERVAL_DAY || result.Period == nil {
+		return result.Date
+	}
+
+	return result.Period.Start
+}
+
+// rangeEnd returns the end of a range which ends at result. An inclusive
+// range ends at the last instant of the day or longer period that result
+// names, so that from june 1 to june 5 holds all of june 5th, while a range
+// which excludes its end stops at the start of that period.
+func rangeEnd(result *Result, inclusive bool) time.Time {
+	if finer(result.Precision, INTERVAL_DAY) != INTERVAL_DAY || result.Period == nil {
+		return result.Date
+	}
+
+	if !inclusive {
+		return result.Period.Start
+	}
+
+	if result.Period.Inclusive {
+		return result.Period.End
+	}
+
+	return result.Period.End.Add(-time.Nanosecond)
+}
+
+// shiftYears moves a result, along with the period covering it, by years
+func shiftYears(result *Result, years int) {
+	result.Date = result.Date.AddDate(years, 0, 0)
+	if result.Period != nil {
+		result.Period = &Range{
+			Start:     result.Period.Start.AddDate(years, 0, 0),
+			End:       result.Period.End.AddDate(years, 0, 0),
+			Inclusive: result.Period.Inclusive,
+		}
+	}
+}
+
+// monthWord returns the first word which names a month, if any
+func (c *Classifier) monthWord(words []string) string {
+	iterator := newTokenIterator(words)
+	for index, word := range words {
+		iterator.MoveN(index - iterator.Index())
+		if _, _, err := c.lang().ClassifyWordAsInteger(word); err == nil {
+			continue
+		}
+
+		if _, err := c.lang().ClassifyAsMonth(iterator); err == nil {
+			return word
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,137 @@
+package datelp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClassifierRange(t *testing.T) {
+	ref := sunday
+	day := func(year int, month time.Month, monthday int) time.Time {
+		return time.Date(year, month, monthday, 0, 0, 0, 0, time.UTC)
+	}
+
+	// an inclusive range ends at the last instant of its last day
+	last := func(year int, month time.Month, monthday int) time.Time {
+		return day(year, month, monthday+1).Add(-time.Nanosecond)
+	}
+
+	testCases := []struct {
+		input     string
+		start     time.Time
+		end       time.Time
+		inclusive bool
+		size      int
+	}{
+		{"from june 1 to june 5", day(2015, time.June, 1), last(2015, time.June, 5), true, 6},
+		{"between monday and friday", day(2015, time.June, 1), last(2015, time.June, 5), true, 4},
+		{"june 1st through june 3rd", day(2015, time.June, 1), last(2015, time.June, 3), true, 5},
+		{"june 30 - july 2", day(2015, time.June, 30), last(2015, time.July, 2), true, 5},
+		{"june 1-5 2015", day(2015, time.June, 1), last(2015, time.June, 5), true, 3},
+		{"1-5 june", day(2015, time.June, 1), last(2015, time.June, 5), true, 2},
+		{"june 1 - 5 2016", day(2016, time.June, 1), last(2016, time.June, 5), true, 5},
+		{"december 30 2015 to january 2 2016", day(2015, time.December, 30), last(2016, time.January, 2), true, 7},
+		{"dec 30 - jan 2", day(2015, time.December, 30), last(2016, time.January, 2), true, 5},
+		{"dec 30 - jan 2 2016", day(2015, time.December, 30), last(2016, time.January, 2), true, 6},
+		{"dec 30 2015 - jan 2", day(2015, time.December, 30), last(2016, time.January, 2), true, 6},
+		{"june to august", day(2015, time.June, 1), last(2015, time.August, 31), true, 3},
+		{"today until friday", day(2015, time.May, 31), day(2015, time.June, 5), false, 3},
+		{"tomorrow 3pm to 5pm", time.Date(2015, time.June, 1, 15, 0, 0, 0, time.UTC), time.Date(2015, time.June, 1, 17, 0, 0, 0, time.UTC), true, 4},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+			continue
+		}
+
+		if res.Kind != KIND_RANGE || res.Range == nil {
+			t.Errorf("\"%s\" was not parsed as a range", tc.input)
+			continue
+		}
+
+		r := res.Range
+		if !r.Start.Equal(tc.start) || !r.End.Equal(tc.end) || r.Inclusive != tc.inclusive || res.Size != tc.size {
+			t.Errorf("Did not convert \"%s\". Expected: %s - %s (%t, %d) Actual: %s - %s (%t, %d)",
+				tc.input, tc.start, tc.end, tc.inclusive, tc.size, r.Start, r.End, r.Inclusive, res.Size)
+		}
+	}
+}
+
+func TestClassifierNotRange(t *testing.T) {
+	ref := sunday
+
+	for _, input := range []string{"2 weeks from today", "from 1 to 5", "next tuesday and friday"} {
+		c := NewClassifier(WithReference(ref))
+		res, _ := c.Parse(newWordIterator(input))
+		if res != nil && res.Range != nil {
+			t.Errorf("\"%s\" should not have been parsed as a range", input)
+		}
+	}
+}
+
+func TestClassifierBackwardsRange(t *testing.T) {
+	ref := sunday
+
+	for _, input := range []string{"june 5 - june 1", "from june 5 2015 to june 1 2015"} {
+		c := NewClassifier(WithReference(ref))
+		_, err := c.Parse(newWordIterator(input))
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("\"%s\" should have been rejected as backwards. actual: %v", input, err)
+		}
+	}
+}
+
+func TestClassifierRangeContains(t *testing.T) {
+	ref := sunday
+
+	testCases := []struct {
+		input    string
+		t        time.Time
+		expected bool
+	}{
+		{"from june 1 to june 5", time.Date(2015, time.June, 5, 12, 0, 0, 0, time.UTC), true},
+		{"from june 1 to june 5", time.Date(2015, time.June, 6, 0, 0, 0, 0, time.UTC), false},
+		{"between monday and friday", time.Date(2015, time.June, 5, 17, 0, 0, 0, time.UTC), true},
+		{"between monday and friday", time.Date(2015, time.June, 1, 9, 0, 0, 0, time.UTC), true},
+		{"today until friday", time.Date(2015, time.June, 5, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		r, err := ParseRangeAt(tc.input, ref)
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+			continue
+		}
+
+		if r.Contains(tc.t) != tc.expected {
+			t.Errorf("\"%s\" contains %s should be %t", tc.input, tc.t, tc.expected)
+		}
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	start := time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2015, time.June, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		r        Range
+		t        time.Time
+		expected bool
+	}{
+		{Range{start, end, true}, start, true},
+		{Range{start, end, true}, end, true},
+		{Range{start, end, false}, end, false},
+		{Range{start, end, false}, start.AddDate(0, 0, -1), false},
+		{Range{start, end, false}, start.AddDate(0, 0, 2), true},
+	}
+
+	for _, tc := range testCases {
+		if tc.r.Contains(tc.t) != tc.expected {
+			t.Errorf("Range %s - %s (%t) contains %s should be %t", tc.r.Start, tc.r.End, tc.r.Inclusive, tc.t, tc.expected)
+		}
+	}
+}
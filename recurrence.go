@@ -0,0 +1,546 @@
+package datelp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FREQUENCY_DAILY = iota << 1
+	FREQUENCY_WEEKLY
+	FREQUENCY_MONTHLY
+	FREQUENCY_YEARLY
+)
+
+// the furthest into the future that Next looks for an occurrence, in years
+const recurrenceHorizon = 8
+
+// Recurrence is a rule for a date that repeats, such as "every other
+// tuesday". It is modelled after the RRULE of RFC 5545 and can be serialized
+// to one with RRule.
+type Recurrence struct {
+	Frequency int            // one of the FREQUENCY_* constants
+	Interval  int            // number of periods between occurrences, eg: 2 for every other week
+	Weekdays  []time.Weekday // weekdays that occurrences fall on, if restricted
+	Position  int            // for monthly rules, which of the weekdays in the month. eg: 2 or -1 for last
+	Start     time.Time      // first instant that can be an occurrence, also sets the time of day
+	Until     time.Time      // last instant that can be an occurrence, zero when never ending
+}
+
+// Between returns every occurrence in [after, before)
+func (r Recurrence) Between(after, before time.Time) []time.Time {
+	occurrences := make([]time.Time, 0)
+	r.each(after, before, func(occurrence time.Time) bool {
+		occurrences = append(occurrences, occurrence)
+		return true
+	})
+
+	return occurrences
+}
+
+// Next returns the first occurrence at or after t, or the zero time when
+// there is none
+func (r Recurrence) Next(t time.Time) time.Time {
+	var next time.Time
+	horizon := t.AddDate(recurrenceHorizon*MaxInt(r.Interval, 1), 0, 0)
+
+	r.each(t, horizon, func(occurrence time.Time) bool {
+		next = occurrence
+		return false
+	})
+
+	return next
+}
+
+// each calls fn with every occurrence in [after, before) until fn returns false
+func (r Recurrence) each(after, before time.Time, fn func(time.Time) bool) {
+	location := r.Start.Location()
+	day := r.Start
+
+	// jump straight to the day of after, keeping the time of day of Start
+	if after.After(day) {
+		after := after.In(location)
+		day = time.Date(after.Year(), after.Month(), after.Day(),
+			r.Start.Hour(), r.Start.Minute(), r.Start.Second(), r.Start.Nanosecond(), location)
+	}
+
+	for ; day.Before(before); day = day.AddDate(0, 0, 1) {
+		if !r.Until.IsZero() && day.After(r.Until) {
+			return
+		}
+
+		if day.Before(after) || day.Before(r.Start) || !r.matches(day) {
+			continue
+		}
+
+		if !fn(day) {
+			return
+		}
+	}
+}
+
+func (r Recurrence) matches(day time.Time) bool {
+	interval := MaxInt(r.Interval, 1)
+
+	if len(r.Weekdays) > 0 && !containsWeekday(r.Weekdays, day.Weekday()) {
+		return false
+	}
+
+	switch r.Frequency {
+	case FREQUENCY_DAILY:
+		return daysBetween(r.Start, day)%interval == 0
+	case FREQUENCY_WEEKLY:
+		if len(r.Weekdays) == 0 && day.Weekday() != r.Start.Weekday() {
+			return false
+		}
+
+		// weeks are counted from monday, as is the default in RFC 5545
+		weeks := (daysBetween(r.Start, day) + (int(r.Start.Weekday())+6)%7) / 7
+		return weeks%interval == 0
+	case FREQUENCY_MONTHLY:
+		months := (day.Year()-r.Start.Year())*12 + int(day.Month()) - int(r.Start.Month())
+		if months%interval != 0 {
+			return false
+		}
+
+		if len(r.Weekdays) == 0 {
+			return day.Day() == r.Start.Day()
+		}
+
+		return r.Position == 0 || r.Position == weekdayPosition(day, r.Position < 0)
+	case FREQUENCY_YEARLY:
+		return (day.Year()-r.Start.Year())%interval == 0 &&
+			day.Month() == r.Start.Month() && day.Day() == r.Start.Day()
+	}
+
+	return false
+}
+
+// RRule serializes the recurrence as an RFC 5545 RRULE value, eg:
+// FREQ=WEEKLY;INTERVAL=2;BYDAY=TU
+func (r Recurrence) RRule() string {
+	frequencies := map[int]string{
+		FREQUENCY_DAILY:   "DAILY",
+		FREQUENCY_WEEKLY:  "WEEKLY",
+		FREQUENCY_MONTHLY: "MONTHLY",
+		FREQUENCY_YEARLY:  "YEARLY",
+	}
+	weekdays := []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+	parts := []string{"FREQ=" + frequencies[r.Frequency]}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if len(r.Weekdays) > 0 {
+		days := make([]string, len(r.Weekdays))
+		for index, weekday := range r.Weekdays {
+			days[index] = weekdays[weekday]
+			if r.Position != 0 {
+				days[index] = strconv.Itoa(r.Position) + days[index]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (c *Classifier) parseRecurrence(i Iterator) (*Recurrence, int, error) {
+	/*
+	   Parse a recurrence starting at the current position of the iterator
+	   without moving it. Returns the recurrence and the number of words of
+	   the iterator that it spans:
+
+	           every tuesday
+	           every other week
+	           every 2nd monday of the month
+	           on mondays
+	           daily until june 30
+	           every weekday except fridays
+	*/
+	words := make([]string, 0)
+	for n := 0; n < rangeWindow; n++ {
+		word, err := i.NextNth(n)
+		if err != nil {
+			break
+		}
+		words = append(words, word)
+	}
+
+	start := c.reference()
+	r := &Recurrence{
+		Frequency: -1,
+		Interval:  1,
+		Start:     time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()),
+	}
+
+	l := c.lang()
+	iterator := newTokenIterator(words)
+	pos := 0
+
+	switch {
+	case len(words) == 0:
+		return nil, 0, ErrOutOfRange
+	case contains(l.Every, words[0]):
+		end, err := r.parseEvery(l, iterator, 1)
+		if err != nil {
+			err.Index += i.Index()
+			return nil, 0, err
+		}
+		pos = end
+	case contains(l.On, words[0]):
+		iterator.Move()
+		if _, err := l.ClassifyAsPluralWeekday(iterator); err == nil {
+			r.Frequency = FREQUENCY_WEEKLY
+			r.Weekdays, pos = parseWeekdays(l, iterator, 1)
+		}
+	default:
+		if frequency, err := l.ClassifyAsFrequency(iterator); err == nil {
+			r.Frequency = frequency
+			pos = 1
+			break
+		}
+
+		// a plural weekday which is counted, such as 2 tuesdays ago, is
+		// an offset rather than a recurrence
+		if _, err := l.ClassifyAsPluralWeekday(iterator); err == nil {
+			if prev, err := i.Prev(); err != nil || !isInteger(l, prev) {
+				r.Frequency = FREQUENCY_WEEKLY
+				r.Weekdays, pos = parseWeekdays(l, iterator, 0)
+			}
+		}
+	}
+
+	if r.Frequency < 0 {
+		return nil, 0, newParseError(ErrUnrecognized, i, "recurrence")
+	}
+
+	pos, err := c.parseRecurrenceModifiers(r, iterator, pos)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return r, pos, nil
+}
+
+// parseEvery parses what follows "every", such as "other week" or "2nd monday
+// of the month", and returns the position after it
+func (r *Recurrence) parseEvery(l *Language, i *WordIterator, pos int) (int, *ParseError) {
+	count := 0
+	ordinal := false
+
+	// common words may follow, eg: todos los martes
+	for pos < len(i.tokens) {
+		i.MoveN(pos - i.Index())
+		if _, err := l.ClassifyAsCommon(i); err != nil {
+			break
+		}
+		pos += 1
+	}
+
+	if pos >= len(i.tokens) {
+		return pos, nil
+	}
+
+	i.MoveN(pos - i.Index())
+	switch value, size, err := l.ClassifyAsIntegerStem(i); {
+	case contains(l.Other, i.Current()):
+		count = 2
+		pos += 1
+	case contains(l.Ordinals[-1], i.Current()):
+		count = -1
+		ordinal = true
+		pos += 1
+	case err == nil:
+		if value == 0 {
+			return pos, newParseError(ErrInvalidOffset, i, "interval")
+		}
+
+		// a number written as a word or with a suffix, such as first or
+		// 2nd, is a position rather than a count
+		_, atoiErr := strconv.Atoi(i.Current())
+		ordinal = atoiErr != nil
+		count = value
+		pos += size
+	}
+
+	if i.MoveN(pos-i.Index()) != nil {
+		return pos, nil
+	}
+
+	if weekdays, err := l.ClassifyAsDayGroup(i); err == nil {
+		r.Frequency = FREQUENCY_WEEKLY
+		r.Weekdays = constantsToWeekdays(weekdays)
+		return pos + 1, nil
+	}
+
+	if _, err := l.ClassifyAsWeekday(i); err == nil {
+		r.Frequency = FREQUENCY_WEEKLY
+		r.Weekdays, pos = parseWeekdays(l, i, pos)
+
+		// every 2nd monday of the month refers to a position within
+		// the month, rather than to every other monday
+		end, err := parseOfTheMonth(l, i, pos)
+		if err == nil {
+			pos = end
+		}
+
+		// as does every first friday, while every 2 fridays is every
+		// other friday
+		if count != 0 && (err == nil || ordinal) {
+			r.Frequency = FREQUENCY_MONTHLY
+			r.Position = count
+			return pos, nil
+		}
+
+		r.Interval = MaxInt(count, 1)
+		return pos, nil
+	}
+
+	if interval, err := l.ClassifyAsInterval(i); err == nil && count >= 0 {
+		frequencies := map[int]int{
+			INTERVAL_DAY:   FREQUENCY_DAILY,
+			INTERVAL_WEEK:  FREQUENCY_WEEKLY,
+			INTERVAL_MONTH: FREQUENCY_MONTHLY,
+			INTERVAL_YEAR:  FREQUENCY_YEARLY,
+		}
+
+		if frequency, exists := frequencies[interval]; exists {
+			r.Frequency = frequency
+			r.Interval = MaxInt(count, 1)
+			return pos + 1, nil
+		}
+	}
+
+	return pos, nil
+}
+
+// parseRecurrenceModifiers parses any of the phrases which can follow a
+// recurrence, such as "until june 30", "except fridays" or "at 9am", and
+// returns the position after them
+func (c *Classifier) parseRecurrenceModifiers(r *Recurrence, i *WordIterator, pos int) (int, error) {
+	l := c.lang()
+	for pos < len(i.tokens) {
+		i.MoveN(pos - i.Index())
+
+		if seconds, count, err := l.ClassifyAsTimeOfDay(i); err == nil {
+			r.Start, _ = TimeContext{size: count, seconds: seconds}.Compile(r.Start)
+			pos += count
+			continue
+		}
+
+		switch word := i.Current(); {
+		case contains(l.Until, word), contains(l.To, word):
+			until, count, clock, err := c.parseRecurrenceDate(i, pos+1)
+			if err != nil {
+				return pos, nil
+			}
+
+			// without a time of day the whole of the last day is included
+			if !clock {
+				until = until.AddDate(0, 0, 1).Add(-time.Second)
+			}
+
+			r.Until = until
+			pos += 1 + count
+		case contains(l.Starting, word), contains(l.From, word):
+			start, count, clock, err := c.parseRecurrenceDate(i, pos+1)
+			if err != nil {
+				return pos, nil
+			}
+
+			// keep any time of day that was already given
+			if !clock {
+				seconds := r.Start.Hour()*3600 + r.Start.Minute()*60 + r.Start.Second()
+				start, _ = TimeContext{seconds: seconds}.Compile(start)
+			}
+
+			r.Start = start
+			pos += 1 + count
+		case contains(l.Except, word):
+			if i.Move() != nil {
+				return pos, nil
+			}
+
+			var excluded []time.Weekday
+			count := 0
+			if weekdays, err := l.ClassifyAsDayGroup(i); err == nil {
+				excluded = constantsToWeekdays(weekdays)
+				count = 1
+			} else if _, err := l.ClassifyAsWeekday(i); err == nil {
+				var end int
+				excluded, end = parseWeekdays(l, i, pos+1)
+				count = end - pos - 1
+			} else {
+				return pos, nil
+			}
+
+			if len(r.Weekdays) == 0 {
+				r.Weekdays = []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+					time.Thursday, time.Friday, time.Saturday}
+			}
+
+			weekdays := make([]time.Weekday, 0)
+			for _, weekday := range r.Weekdays {
+				if !containsWeekday(excluded, weekday) {
+					weekdays = append(weekdays, weekday)
+				}
+			}
+
+			r.Weekdays = weekdays
+			pos += 1 + count
+		default:
+			// common words may introduce a time of day, eg: at 9am
+			count := 0
+			for ; pos+count < len(i.tokens); count++ {
+				i.MoveN(pos + count - i.Index())
+				if _, err := l.ClassifyAsCommon(i); err != nil {
+					break
+				}
+			}
+
+			if _, _, err := l.ClassifyAsTimeOfDay(i); count == 0 || err != nil {
+				return pos, nil
+			}
+			pos += count
+		}
+	}
+
+	return pos, nil
+}
+
+// parseRecurrenceDate classifies the date starting at pos in isolation and
+// returns it along with the number of words it spans and whether it had a
+// time of day
+func (c *Classifier) parseRecurrenceDate(i *WordIterator, pos int) (time.Time, int, bool, error) {
+	words := make([]string, 0)
+	for _, token := range i.tokens[MinInt(pos, len(i.tokens)):] {
+		words = append(words, token.Text)
+	}
+
+	if len(words) == 0 {
+		return time.Time{}, 0, false, ErrNoDate
+	}
+
+	fork := c.fork()
+	if err := fork.buildContexts(newTokenIterator(words)); err != nil || !fork.anchored {
+		return time.Time{}, 0, false, ErrNoDate
+	}
+
+	result, err := fork.compile()
+	if err != nil {
+		return time.Time{}, 0, false, err
+	}
+
+	date := result.Date
+	if fork.clock.size == 0 {
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	}
+
+	return date, fork.last + 1, fork.clock.size > 0, nil
+}
+
+// parseWeekdays parses a list of weekdays starting at pos, such as "monday
+// and wednesday", and returns them along with the position after the list
+func parseWeekdays(l *Language, i *WordIterator, pos int) ([]time.Weekday, int) {
+	weekdays := make([]time.Weekday, 0)
+
+	for pos < len(i.tokens) {
+		i.MoveN(pos - i.Index())
+		value, err := l.ClassifyAsWeekday(i)
+		if err != nil {
+			break
+		}
+
+		weekday, _ := ConstantToWeekday(value)
+		weekdays = append(weekdays, weekday)
+		pos += 1
+
+		// continue past conjunctions only when another weekday follows
+		if next, err := i.NextNth(2); err == nil && pos < len(i.tokens) {
+			conjunction := i.tokens[pos].Text
+			if _, err := l.ClassifyAsWeekday(newTokenIterator([]string{next})); err == nil && (contains(l.And, conjunction) || contains(l.Or, conjunction)) {
+				pos += 1
+				continue
+			}
+		}
+		break
+	}
+
+	return weekdays, pos
+}
+
+// parseOfTheMonth parses a trailing "of the month" starting at pos, and
+// returns the position after it
+func parseOfTheMonth(l *Language, i *WordIterator, pos int) (int, error) {
+	of := false
+	for pos < len(i.tokens) {
+		i.MoveN(pos - i.Index())
+		if _, err := l.ClassifyAsCommon(i); err != nil && !contains(l.Of, i.Current()) {
+			break
+		}
+		of = of || contains(l.Of, i.Current())
+		pos += 1
+	}
+
+	if pos >= len(i.tokens) || !of {
+		return pos, newParseError(ErrUnrecognized, i, "of the month")
+	}
+
+	if interval, err := l.ClassifyAsInterval(i); err != nil || interval != INTERVAL_MONTH {
+		return pos, newParseError(ErrUnrecognized, i, "of the month")
+	}
+
+	return pos + 1, nil
+}
+
+// weekdayPosition returns which of its weekday in the month day is, counting
+// from the start of the month or backwards from the end as negatives
+func weekdayPosition(day time.Time, fromEnd bool) int {
+	if !fromEnd {
+		return (day.Day()-1)/7 + 1
+	}
+
+	days := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return -((days-day.Day())/7 + 1)
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	from := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, candidate := range weekdays {
+		if candidate == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+func constantsToWeekdays(constants []int) []time.Weekday {
+	weekdays := make([]time.Weekday, 0)
+	for _, constant := range constants {
+		weekday, _ := ConstantToWeekday(constant)
+		weekdays = append(weekdays, weekday)
+	}
+
+	return weekdays
+}
+
+func isInteger(l *Language, word string) bool {
+	_, _, err := l.ClassifyWordAsInteger(word)
+	return err == nil
+}
@@ -0,0 +1,124 @@
+package datelp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClassifierRecurrence(t *testing.T) {
+	ref := sunday
+	day := func(month time.Month, monthday int) time.Time {
+		return time.Date(2015, month, monthday, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		input       string
+		rrule       string
+		occurrences []time.Time
+		size        int
+	}{
+		{"every tuesday", "FREQ=WEEKLY;BYDAY=TU",
+			[]time.Time{day(time.June, 2), day(time.June, 9), day(time.June, 16)}, 2},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2",
+			[]time.Time{day(time.June, 14), day(time.June, 28)}, 3},
+		{"every 2nd monday of the month", "FREQ=MONTHLY;BYDAY=2MO",
+			[]time.Time{day(time.June, 8), day(time.July, 13)}, 6},
+		{"every last friday of the month", "FREQ=MONTHLY;BYDAY=-1FR",
+			[]time.Time{day(time.June, 26), day(time.July, 31)}, 6},
+		{"every first friday", "FREQ=MONTHLY;BYDAY=1FR",
+			[]time.Time{day(time.June, 5), day(time.July, 3)}, 3},
+		{"every last friday", "FREQ=MONTHLY;BYDAY=-1FR",
+			[]time.Time{day(time.June, 26), day(time.July, 31)}, 3},
+		{"every 2 fridays", "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+			[]time.Time{day(time.June, 12), day(time.June, 26)}, 3},
+		{"on mondays and wednesdays", "FREQ=WEEKLY;BYDAY=MO,WE",
+			[]time.Time{day(time.June, 1), day(time.June, 3), day(time.June, 8)}, 4},
+		{"daily until june 3", "FREQ=DAILY;UNTIL=20150603T235959Z",
+			[]time.Time{day(time.June, 1), day(time.June, 2), day(time.June, 3)}, 4},
+		{"every weekday except fridays", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH",
+			[]time.Time{day(time.June, 1), day(time.June, 2), day(time.June, 3), day(time.June, 4), day(time.June, 8)}, 4},
+		{"every 3 days at 9am", "FREQ=DAILY;INTERVAL=3",
+			[]time.Time{time.Date(2015, time.June, 3, 9, 0, 0, 0, time.UTC), time.Date(2015, time.June, 6, 9, 0, 0, 0, time.UTC)}, 5},
+		{"tuesdays", "FREQ=WEEKLY;BYDAY=TU",
+			[]time.Time{day(time.June, 2), day(time.June, 9), day(time.June, 16)}, 1},
+	}
+
+	for _, tc := range testCases {
+		c := NewClassifier(WithReference(ref))
+		res, err := c.Parse(newWordIterator(tc.input))
+		if err != nil {
+			t.Errorf("Unexpected error returned for \"%s\": %s", tc.input, err)
+			continue
+		}
+
+		if res.Kind != KIND_RECURRENCE || res.Recurrence == nil {
+			t.Errorf("\"%s\" was not parsed as a recurrence", tc.input)
+			continue
+		}
+
+		if rrule := res.Recurrence.RRule(); rrule != tc.rrule || res.Size != tc.size {
+			t.Errorf("Did not convert \"%s\". Expected: %s (%d) Actual: %s (%d)", tc.input, tc.rrule, tc.size, rrule, res.Size)
+		}
+
+		if !res.Date.Equal(res.Recurrence.Next(ref)) || res.Date.Before(ref) {
+			t.Errorf("\"%s\" resolved to %s which is not the next occurrence", tc.input, res.Date)
+		}
+
+		occurrences := res.Recurrence.Between(ref, tc.occurrences[len(tc.occurrences)-1].Add(time.Second))
+		if len(occurrences) != len(tc.occurrences) {
+			t.Errorf("\"%s\" had wrong occurrences. Expected: %v Actual: %v", tc.input, tc.occurrences, occurrences)
+			continue
+		}
+
+		for index, occurrence := range occurrences {
+			if !occurrence.Equal(tc.occurrences[index]) {
+				t.Errorf("\"%s\" had wrong occurrence. Expected: %s Actual: %s", tc.input, tc.occurrences[index], occurrence)
+			}
+		}
+	}
+}
+
+func TestClassifierNotRecurrence(t *testing.T) {
+	ref := sunday
+
+	for _, input := range []string{"2 tuesdays ago", "next tuesday", "every", "on monday"} {
+		c := NewClassifier(WithReference(ref))
+		res, _ := c.Parse(newWordIterator(input))
+		if res != nil && res.Recurrence != nil {
+			t.Errorf("\"%s\" should not have been parsed as a recurrence", input)
+		}
+	}
+}
+
+func TestClassifierInvalidRecurrence(t *testing.T) {
+	ref := sunday
+
+	for _, input := range []string{"every 0 days", "remind me every 0 weeks"} {
+		c := NewClassifier(WithReference(ref))
+		_, err := c.Parse(newWordIterator(input))
+
+		var parseErr *ParseError
+		if !errors.Is(err, ErrInvalidOffset) || !errors.As(err, &parseErr) || parseErr.Token != "0" {
+			t.Errorf("\"%s\" should have been rejected. actual: %v", input, err)
+		}
+	}
+}
+
+func TestRecurrenceUntil(t *testing.T) {
+	r := Recurrence{
+		Frequency: FREQUENCY_YEARLY,
+		Interval:  1,
+		Start:     time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC),
+		Until:     time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	occurrences := r.Between(r.Start, r.Start.AddDate(10, 0, 0))
+	if len(occurrences) != 3 {
+		t.Errorf("Recurrence did not stop at Until. Actual: %v", occurrences)
+	}
+
+	if next := r.Next(r.Until.Add(time.Second)); !next.IsZero() {
+		t.Errorf("Recurrence should have no occurrence after Until. Actual: %s", next)
+	}
+}
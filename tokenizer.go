@@ -0,0 +1,182 @@
+package datelp
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// punctuation that is stripped from either end of a word, such as the
+// brackets of "(tomorrow)" or the comma of "june 1st,"
+const (
+	leadingPunctuation  = "\"'`([{<«“‘¿¡*_"
+	trailingPunctuation = "\"'`)]}>»”’,;:!?*_…"
+)
+
+// punctuation between two words which ends a sentence or opens a clause, so
+// that the words do not belong to the same date, unlike the comma of "friday,
+// june 5" or the bracket of "(tomorrow) at 3pm"
+const separatingPunctuation = ".;:!?…([{<«“¿¡"
+
+// suffixes which belong to the number that they are written against, such as
+// the ordinal of 1st or the meridiem of 10am. Any other letters that directly
+// follow a number, such as the weeks of 3weeks, are a word of their own.
+var numberSuffixes = []string{
+	"st", "nd", "rd", "th", "er", "re", "e", "º", "ª",
+	"am", "pm", "a.m.", "p.m.", "a.m", "p.m",
+}
+
+// an ISO week date such as 2016-W23-2 mixes numbers and letters but is a
+// single word
+var isoWeekDate = regexp.MustCompile("^[0-9]{4}-?[wW][0-9]{2}(-?[0-9])?$")
+
+// Tokenize splits the input into words which the leaf classifiers can match
+// exactly. Words are split on whitespace, lower cased and stripped of the
+// punctuation around them, and numbers glued to a word are split apart, so
+// that "(Tomorrow)." reads as tomorrow, "3weeks" as 3 weeks and "June1st," as
+// june 1st. Punctuation that is part of a word, such as that of "p.m.",
+// "o'clock", "14:30", "2015-06-01" or "1-5", is kept. The offsets of each
+// token refer to the original input, and Raw holds the word as written.
+func Tokenize(input string) []Token {
+	tokens := make([]Token, 0)
+
+	start := -1
+	for pos := 0; pos <= len(input); {
+		r, width := utf8.DecodeRuneInString(input[pos:])
+		if pos == len(input) || unicode.IsSpace(r) {
+			if start >= 0 {
+				for _, token := range splitWord(input, start, pos) {
+					if len(tokens) > 0 {
+						previous := tokens[len(tokens)-1]
+						token.Separated = separates(previous, input[previous.End:token.Start])
+					}
+					tokens = append(tokens, token)
+				}
+				start = -1
+			}
+
+			if pos == len(input) {
+				break
+			}
+		} else if start < 0 {
+			start = pos
+		}
+
+		pos += width
+	}
+
+	return tokens
+}
+
+// splitWord returns the tokens of the whitespace separated word input[start:end]
+func splitWord(input string, start, end int) []Token {
+	word := input[start:end]
+
+	// a dash on its own separates the ends of a range
+	switch word {
+	case "-", "–", "—":
+		return []Token{{Text: "-", Raw: word, Start: start, End: end}}
+	}
+
+	for start < end && strings.ContainsRune(leadingPunctuation, firstRune(input[start:end])) {
+		_, width := utf8.DecodeRuneInString(input[start:end])
+		start += width
+	}
+
+	for start < end {
+		word = input[start:end]
+		last, width := utf8.DecodeLastRuneInString(word)
+
+		// the trailing dot of an abbreviation such as p.m. is kept,
+		// unlike a full stop at the end of a sentence
+		isAbbreviation := last == '.' && len(word) > 2 && word[len(word)-3] == '.' && unicode.IsLetter(rune(word[len(word)-2]))
+		if !strings.ContainsRune(trailingPunctuation, last) && (last != '.' || isAbbreviation) {
+			break
+		}
+		end -= width
+	}
+
+	if start >= end {
+		return nil
+	}
+
+	if isoWeekDate.MatchString(input[start:end]) {
+		return []Token{newToken(input, start, end)}
+	}
+
+	// split the word wherever a number meets letters, unless the letters
+	// are the suffix of the number or a single letter such as the q of q3
+	tokens := make([]Token, 0)
+	from := start
+	for pos := start; pos < end; {
+		r, width := utf8.DecodeRuneInString(input[pos:end])
+		next := pos + width
+		if next >= end {
+			break
+		}
+
+		following := firstRune(input[next:end])
+		switch {
+		case unicode.IsDigit(r) && unicode.IsLetter(following) && !isNumberSuffix(input[next:end]):
+		case unicode.IsLetter(r) && unicode.IsDigit(following) && next-from > 1:
+		default:
+			pos = next
+			continue
+		}
+
+		tokens = append(tokens, newToken(input, from, next))
+		from = next
+		pos = next
+	}
+
+	return append(tokens, newToken(input, from, end))
+}
+
+func newToken(input string, start, end int) Token {
+	return Token{
+		Text:  strings.ToLower(input[start:end]),
+		Raw:   input[start:end],
+		Start: start,
+		End:   end,
+	}
+}
+
+// separates returns whether the gap between the previous token and the next
+// one holds separating punctuation. The full stop of a German ordinal such as
+// "2. juni" belongs to its number.
+func separates(previous Token, gap string) bool {
+	if strings.HasPrefix(gap, ".") && isDigits(previous.Text) {
+		gap = gap[1:]
+	}
+
+	return strings.ContainsAny(gap, separatingPunctuation)
+}
+
+func isDigits(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return word != ""
+}
+
+// isNumberSuffix returns whether the letters which follow a number are the
+// suffix of that number
+func isNumberSuffix(letters string) bool {
+	letters = strings.ToLower(letters)
+	for _, suffix := range numberSuffixes {
+		if letters == suffix {
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
@@ -0,0 +1,86 @@
+package datelp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"Tuesday", []string{"tuesday"}},
+		{"June 1st, 2015.", []string{"june", "1st", "2015"}},
+		{"(tomorrow)", []string{"tomorrow"}},
+		{"3weeks ago", []string{"3", "weeks", "ago"}},
+		{"10AM", []string{"10am"}},
+		{"June1st", []string{"june", "1st"}},
+		{"3 p.m.", []string{"3", "p.m."}},
+		{"9 o'clock", []string{"9", "o'clock"}},
+		{"14:30 on 2015-06-01", []string{"14:30", "on", "2015-06-01"}},
+		{"June 1-5 – 2015", []string{"june", "1-5", "-", "2015"}},
+		{"1.6.2015.", []string{"1.6.2015"}},
+		{"¿el próximo martes?", []string{"el", "próximo", "martes"}},
+		{"aujourd'hui", []string{"aujourd'hui"}},
+		{"q3 , ...", []string{"q3"}},
+		{"2016-W23-2, W23", []string{"2016-w23-2", "w23"}},
+	}
+
+	for _, tc := range testCases {
+		words := make([]string, 0)
+		for _, token := range Tokenize(tc.input) {
+			words = append(words, token.Text)
+		}
+
+		if !reflect.DeepEqual(words, tc.expected) {
+			t.Errorf("\"%s\" tokenized wrong. expected: %q actual: %q", tc.input, tc.expected, words)
+		}
+	}
+}
+
+func TestTokenizeOffsets(t *testing.T) {
+	input := "See (June1st), OK"
+	expected := []Token{
+		{"see", "See", 0, 3, false},
+		{"june", "June", 5, 9, true},
+		{"1st", "1st", 9, 12, false},
+		{"ok", "OK", 15, 17, false},
+	}
+
+	tokens := Tokenize(input)
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Fatalf("\"%s\" tokenized wrong. expected: %v actual: %v", input, expected, tokens)
+	}
+
+	for _, token := range tokens {
+		if input[token.Start:token.End] != token.Raw {
+			t.Errorf("token %q does not point at its raw text. actual: %q", token.Raw, input[token.Start:token.End])
+		}
+	}
+}
+
+func TestTokenizeSeparated(t *testing.T) {
+	testCases := []struct {
+		input     string
+		separated []bool
+	}{
+		{"friday, june 5, 2015", []bool{false, false, false, false}},
+		{"(tomorrow) at 3pm", []bool{false, false, false}},
+		{"tomorrow, (june 5th", []bool{false, true, false}},
+		{"done today. tomorrow", []bool{false, false, true}},
+		{"am 2. juni", []bool{false, false, false}},
+		{"see 1-5; 6", []bool{false, false, true}},
+	}
+
+	for _, tc := range testCases {
+		separated := make([]bool, 0)
+		for _, token := range Tokenize(tc.input) {
+			separated = append(separated, token.Separated)
+		}
+
+		if !reflect.DeepEqual(separated, tc.separated) {
+			t.Errorf("\"%s\" separated wrong. expected: %v actual: %v", tc.input, tc.separated, separated)
+		}
+	}
+}
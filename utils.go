@@ -1,7 +1,6 @@
 package datelp
 
 import (
-	"errors"
 	"time"
 )
 
@@ -13,6 +12,24 @@ func MaxInt(a, b int) int {
 	return a
 }
 
+func MinInt(a, b int) int {
+	if a > b {
+		return b
+	}
+
+	return a
+}
+
+func contains(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+
+	return false
+}
+
 func ConstantToWeekday(input int) (time.Weekday, error) {
 	switch {
 	case input == WEEKDAY_SUNDAY:
@@ -31,7 +48,7 @@ func ConstantToWeekday(input int) (time.Weekday, error) {
 		return time.Saturday, nil
 	}
 
-	return time.Sunday, errors.New("")
+	return time.Sunday, ErrInvalidWeekday
 }
 
 func ConstantToMonth(input int) (time.Month, error) {
@@ -62,5 +79,5 @@ func ConstantToMonth(input int) (time.Month, error) {
 		return time.December, nil
 	}
 
-	return time.January, errors.New("Invalid month")
+	return time.January, ErrInvalidMonth
 }
@@ -21,3 +21,21 @@ func TestMaxInt(t *testing.T) {
 		}
 	}
 }
+
+func TestMinInt(t *testing.T) {
+	testCases := []struct {
+		args     [2]int
+		expected int
+	}{
+		{[2]int{1, 2}, 1},
+		{[2]int{3, 2}, 2},
+		{[2]int{2, 2}, 2},
+	}
+
+	for _, tc := range testCases {
+		res := MinInt(tc.args[0], tc.args[1])
+		if res != tc.expected {
+			t.Fail()
+		}
+	}
+}